@@ -16,8 +16,10 @@ func dataSourceAwsDbInstance() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"db_instance_identifier": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"db_instance_identifier", "tags"},
 			},
 
 			"tags": tagsSchemaComputed(),
@@ -219,28 +221,64 @@ func dataSourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error
 	conn := meta.(*AWSClient).rdsconn
 	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
 
-	opts := &rds.DescribeDBInstancesInput{
-		DBInstanceIdentifier: aws.String(d.Get("db_instance_identifier").(string)),
+	opts := &rds.DescribeDBInstancesInput{}
+
+	if v, ok := d.GetOk("db_instance_identifier"); ok {
+		opts.DBInstanceIdentifier = aws.String(v.(string))
 	}
 
+	filterTags := keyvaluetags.New(d.Get("tags").(map[string]interface{}))
+
 	log.Printf("[DEBUG] Reading DB Instance: %s", opts)
 
-	resp, err := conn.DescribeDBInstances(opts)
+	var dbInstances []*rds.DBInstance
+	var listTagsErr error
+
+	err := conn.DescribeDBInstancesPages(opts, func(page *rds.DescribeDBInstancesOutput, lastPage bool) bool {
+		for _, dbInstance := range page.DBInstances {
+			if dbInstance == nil {
+				continue
+			}
+
+			// The DescribeDBInstances API does not return tags or filter by them.
+			if len(filterTags) > 0 {
+				tags, err := keyvaluetags.RdsListTags(conn, aws.StringValue(dbInstance.DBInstanceArn))
+
+				if err != nil {
+					listTagsErr = fmt.Errorf("error listing tags for RDS DB Instance (%s): %w", aws.StringValue(dbInstance.DBInstanceArn), err)
+					return false
+				}
+
+				if !tags.ContainsAll(filterTags) {
+					continue
+				}
+			}
+
+			dbInstances = append(dbInstances, dbInstance)
+		}
+		return !lastPage
+	})
+
 	if err != nil {
 		return err
 	}
 
-	if len(resp.DBInstances) < 1 {
+	if listTagsErr != nil {
+		return listTagsErr
+	}
+
+	if len(dbInstances) < 1 {
 		return fmt.Errorf("Your query returned no results. Please change your search criteria and try again.")
 	}
-	if len(resp.DBInstances) > 1 {
+	if len(dbInstances) > 1 {
 		return fmt.Errorf("Your query returned more than one result. Please try a more specific search criteria.")
 	}
 
-	dbInstance := *resp.DBInstances[0]
+	dbInstance := *dbInstances[0]
 
-	d.SetId(d.Get("db_instance_identifier").(string))
+	d.SetId(aws.StringValue(dbInstance.DBInstanceIdentifier))
 
+	d.Set("db_instance_identifier", dbInstance.DBInstanceIdentifier)
 	d.Set("allocated_storage", dbInstance.AllocatedStorage)
 	d.Set("auto_minor_version_upgrade", dbInstance.AutoMinorVersionUpgrade)
 	d.Set("availability_zone", dbInstance.AvailabilityZone)
@@ -283,10 +321,14 @@ func dataSourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("monitoring_interval", dbInstance.MonitoringInterval)
 	d.Set("monitoring_role_arn", dbInstance.MonitoringRoleArn)
 	d.Set("multi_az", dbInstance.MultiAZ)
-	d.Set("address", dbInstance.Endpoint.Address)
-	d.Set("port", dbInstance.Endpoint.Port)
-	d.Set("hosted_zone_id", dbInstance.Endpoint.HostedZoneId)
-	d.Set("endpoint", fmt.Sprintf("%s:%d", *dbInstance.Endpoint.Address, *dbInstance.Endpoint.Port))
+
+	// The endpoint is not available while the DB instance is being created.
+	if dbInstance.Endpoint != nil {
+		d.Set("address", dbInstance.Endpoint.Address)
+		d.Set("port", dbInstance.Endpoint.Port)
+		d.Set("hosted_zone_id", dbInstance.Endpoint.HostedZoneId)
+		d.Set("endpoint", fmt.Sprintf("%s:%d", aws.StringValue(dbInstance.Endpoint.Address), aws.Int64Value(dbInstance.Endpoint.Port)))
+	}
 
 	if err := d.Set("enabled_cloudwatch_logs_exports", aws.StringValueSlice(dbInstance.EnabledCloudwatchLogsExports)); err != nil {
 		return fmt.Errorf("error setting enabled_cloudwatch_logs_exports: %#v", err)
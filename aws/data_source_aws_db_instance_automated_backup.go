@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsDbInstanceAutomatedBackup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDbInstanceAutomatedBackupRead,
+
+		Schema: map[string]*schema.Schema{
+			"db_instance_identifier": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"db_instance_identifier", "dbi_resource_id"},
+			},
+
+			"dbi_resource_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"db_instance_identifier", "dbi_resource_id"},
+			},
+
+			"db_instance_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"earliest_restorable_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"engine": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"engine_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"kms_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"latest_restorable_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDbInstanceAutomatedBackupRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	input := &rds.DescribeDBInstanceAutomatedBackupsInput{}
+
+	if v, ok := d.GetOk("db_instance_identifier"); ok {
+		input.DBInstanceIdentifier = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("dbi_resource_id"); ok {
+		input.DbiResourceId = aws.String(v.(string))
+	}
+
+	log.Printf("[DEBUG] Reading RDS DB Instance Automated Backups: %v", input)
+	var automatedBackups []*rds.DBInstanceAutomatedBackup
+
+	err := conn.DescribeDBInstanceAutomatedBackupsPages(input, func(resp *rds.DescribeDBInstanceAutomatedBackupsOutput, lastPage bool) bool {
+		for _, automatedBackup := range resp.DBInstanceAutomatedBackups {
+			if automatedBackup == nil {
+				continue
+			}
+
+			automatedBackups = append(automatedBackups, automatedBackup)
+		}
+		return !lastPage
+	})
+
+	if isAWSErr(err, rds.ErrCodeDBInstanceAutomatedBackupNotFoundFault, "") {
+		return fmt.Errorf("no RDS DB Instance Automated Backup found matching criteria; try different search")
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS DB Instance Automated Backups: %w", err)
+	}
+
+	if len(automatedBackups) == 0 {
+		return fmt.Errorf("no RDS DB Instance Automated Backup found matching criteria; try different search")
+	}
+
+	// Retained automated backups of deleted instances can share an identifier.
+	if len(automatedBackups) > 1 {
+		return fmt.Errorf("multiple RDS DB Instance Automated Backups (%d) match the criteria; try searching by dbi_resource_id", len(automatedBackups))
+	}
+
+	automatedBackup := automatedBackups[0]
+
+	d.SetId(aws.StringValue(automatedBackup.DbiResourceId))
+	d.Set("db_instance_arn", automatedBackup.DBInstanceArn)
+	d.Set("db_instance_identifier", automatedBackup.DBInstanceIdentifier)
+	d.Set("dbi_resource_id", automatedBackup.DbiResourceId)
+	d.Set("encrypted", automatedBackup.Encrypted)
+	d.Set("engine", automatedBackup.Engine)
+	d.Set("engine_version", automatedBackup.EngineVersion)
+	d.Set("kms_key_id", automatedBackup.KmsKeyId)
+	d.Set("region", automatedBackup.Region)
+	d.Set("status", automatedBackup.Status)
+
+	d.Set("earliest_restorable_time", "")
+	d.Set("latest_restorable_time", "")
+	if restoreWindow := automatedBackup.RestoreWindow; restoreWindow != nil {
+		if restoreWindow.EarliestTime != nil {
+			d.Set("earliest_restorable_time", aws.TimeValue(restoreWindow.EarliestTime).Format(time.RFC3339))
+		}
+
+		if restoreWindow.LatestTime != nil {
+			d.Set("latest_restorable_time", aws.TimeValue(restoreWindow.LatestTime).Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
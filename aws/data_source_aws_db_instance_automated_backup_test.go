@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAWSDbInstanceAutomatedBackupDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_db_instance_automated_backup.test"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDbInstanceAutomatedBackupDataSourceConfig(rName, "db_instance_identifier = aws_db_instance.test.identifier"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "db_instance_arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "db_instance_identifier", resourceName, "identifier"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "dbi_resource_id", resourceName, "resource_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "engine", resourceName, "engine"),
+					resource.TestCheckResourceAttr(dataSourceName, "encrypted", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "region", testAccGetRegion()),
+					resource.TestCheckResourceAttr(dataSourceName, "status", "active"),
+					resource.TestMatchResourceAttr(dataSourceName, "latest_restorable_time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+			{
+				Config: testAccAWSDbInstanceAutomatedBackupDataSourceConfig(rName, "dbi_resource_id = aws_db_instance.test.resource_id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "db_instance_identifier", resourceName, "identifier"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "dbi_resource_id", resourceName, "resource_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSDbInstanceAutomatedBackupDataSourceConfig(rName, lookup string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = %[1]q
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+
+data "aws_db_instance_automated_backup" "test" {
+  %[2]s
+}
+`, rName, lookup)
+}
@@ -32,6 +32,9 @@ func TestAccAWSDbInstanceDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.aws_db_instance.bar", "multi_az"),
 					resource.TestCheckResourceAttrSet("data.aws_db_instance.bar", "enabled_cloudwatch_logs_exports.0"),
 					resource.TestCheckResourceAttrSet("data.aws_db_instance.bar", "enabled_cloudwatch_logs_exports.1"),
+					resource.TestCheckResourceAttrPair("data.aws_db_instance.bar", "address", "aws_db_instance.bar", "address"),
+					resource.TestCheckResourceAttrPair("data.aws_db_instance.bar", "port", "aws_db_instance.bar", "port"),
+					resource.TestCheckResourceAttrPair("data.aws_db_instance.bar", "hosted_zone_id", "aws_db_instance.bar", "hosted_zone_id"),
 					resource.TestCheckResourceAttrPair("data.aws_db_instance.bar", "resource_id", "aws_db_instance.bar", "resource_id"),
 					resource.TestCheckResourceAttrPair("data.aws_db_instance.bar", "tags.%", "aws_db_instance.bar", "tags.%"),
 					resource.TestCheckResourceAttrPair("data.aws_db_instance.bar", "tags.Environment", "aws_db_instance.bar", "tags.Environment"),
@@ -41,6 +44,29 @@ func TestAccAWSDbInstanceDataSource_basic(t *testing.T) {
 	})
 }
 
+func TestAccAWSDbInstanceDataSource_Tags(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_db_instance.test"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceDataSourceConfigTags(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "db_instance_identifier", resourceName, "identifier"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "db_instance_arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "address", resourceName, "address"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.Name", rName),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSDbInstanceDataSource_ec2Classic(t *testing.T) {
 	oldvar := os.Getenv("AWS_DEFAULT_REGION")
 	os.Setenv("AWS_DEFAULT_REGION", "us-east-1")
@@ -102,3 +128,28 @@ data "aws_db_instance" "bar" {
 }
 `, testAccAWSDBInstanceConfigEc2Classic(rInt))
 }
+
+func testAccAWSDBInstanceDataSourceConfigTags(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 10
+  engine              = "mariadb"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+
+  tags = {
+    Name        = %[1]q
+    Environment = "test"
+  }
+}
+
+data "aws_db_instance" "test" {
+  tags = {
+    Name = aws_db_instance.test.tags["Name"]
+  }
+}
+`, rName)
+}
@@ -0,0 +1,229 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsRdsCertificates() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsRdsCertificatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"certificates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"certificate_identifier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"certificate_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"customer_override": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"customer_override_valid_till": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"thumbprint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"valid_from": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"valid_till": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"latest_valid_till": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsRdsCertificatesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	certificates, err := meta.(*AWSClient).rdsCertificatesCache.get(func() ([]*rds.Certificate, error) {
+		return describeRdsCertificates(conn)
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS Certificates: %w", err)
+	}
+
+	if d.Get("latest_valid_till").(bool) {
+		certificates = rdsCertificatesLatestValidTill(certificates)
+	}
+
+	var ids []string
+	for _, certificate := range certificates {
+		ids = append(ids, aws.StringValue(certificate.CertificateIdentifier))
+	}
+
+	d.SetId(meta.(*AWSClient).region)
+
+	if err := d.Set("certificates", flattenRdsCertificates(certificates)); err != nil {
+		return fmt.Errorf("error setting certificates: %w", err)
+	}
+
+	d.Set("ids", ids)
+
+	return nil
+}
+
+// describeRdsCertificates returns all CA certificates available in the region.
+func describeRdsCertificates(conn *rds.RDS) ([]*rds.Certificate, error) {
+	input := &rds.DescribeCertificatesInput{}
+
+	log.Printf("[DEBUG] Reading RDS Certificates: %s", input)
+	var certificates []*rds.Certificate
+
+	err := conn.DescribeCertificatesPages(input, func(page *rds.DescribeCertificatesOutput, lastPage bool) bool {
+		for _, certificate := range page.Certificates {
+			if certificate == nil {
+				continue
+			}
+
+			certificates = append(certificates, certificate)
+		}
+		return !lastPage
+	})
+
+	return certificates, err
+}
+
+// rdsCertificatesCache memoizes DescribeCertificates results for the lifetime
+// of the provider, so that aws_db_instance can validate and read
+// ca_cert_identifier using the certificates already read by the
+// aws_rds_certificates data source. Concurrent reads share a single API call.
+// Errors are not cached.
+type rdsCertificatesCache struct {
+	mu    sync.Mutex
+	entry *rdsCertificatesCacheEntry
+}
+
+type rdsCertificatesCacheEntry struct {
+	once         sync.Once
+	certificates []*rds.Certificate
+	err          error
+}
+
+func newRdsCertificatesCache() *rdsCertificatesCache {
+	return &rdsCertificatesCache{}
+}
+
+// get returns the cached certificates, calling fetch to populate them.
+func (c *rdsCertificatesCache) get(fetch func() ([]*rds.Certificate, error)) ([]*rds.Certificate, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mu.Lock()
+	entry := c.entry
+	if entry == nil {
+		entry = &rdsCertificatesCacheEntry{}
+		c.entry = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.certificates, entry.err = fetch()
+	})
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entry == entry {
+			c.entry = nil
+		}
+		c.mu.Unlock()
+	}
+
+	return entry.certificates, entry.err
+}
+
+// rdsCertificatesLatestValidTill returns the certificate which is valid the
+// longest, which is usually the newest CA to rotate DB instances to.
+func rdsCertificatesLatestValidTill(certificates []*rds.Certificate) []*rds.Certificate {
+	var latest *rds.Certificate
+
+	for _, certificate := range certificates {
+		if certificate == nil || certificate.ValidTill == nil {
+			continue
+		}
+
+		if latest == nil || aws.TimeValue(certificate.ValidTill).After(aws.TimeValue(latest.ValidTill)) {
+			latest = certificate
+		}
+	}
+
+	if latest == nil {
+		return nil
+	}
+
+	return []*rds.Certificate{latest}
+}
+
+func flattenRdsCertificates(certificates []*rds.Certificate) []interface{} {
+	var tfList []interface{}
+
+	for _, certificate := range certificates {
+		if certificate == nil {
+			continue
+		}
+
+		tfMap := map[string]interface{}{
+			"arn":                    aws.StringValue(certificate.CertificateArn),
+			"certificate_identifier": aws.StringValue(certificate.CertificateIdentifier),
+			"certificate_type":       aws.StringValue(certificate.CertificateType),
+			"customer_override":      aws.BoolValue(certificate.CustomerOverride),
+			"thumbprint":             aws.StringValue(certificate.Thumbprint),
+		}
+
+		if certificate.CustomerOverrideValidTill != nil {
+			tfMap["customer_override_valid_till"] = aws.TimeValue(certificate.CustomerOverrideValidTill).Format(time.RFC3339)
+		}
+
+		if certificate.ValidFrom != nil {
+			tfMap["valid_from"] = aws.TimeValue(certificate.ValidFrom).Format(time.RFC3339)
+		}
+
+		if certificate.ValidTill != nil {
+			tfMap["valid_till"] = aws.TimeValue(certificate.ValidTill).Format(time.RFC3339)
+		}
+
+		tfList = append(tfList, tfMap)
+	}
+
+	return tfList
+}
@@ -0,0 +1,165 @@
+package aws
+
+import (
+	"errors"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/tfawsresource"
+)
+
+func TestRdsCertificatesLatestValidTill(t *testing.T) {
+	certificates := []*rds.Certificate{
+		{CertificateIdentifier: aws.String("rds-ca-2015"), ValidTill: aws.Time(time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC))},
+		{CertificateIdentifier: aws.String("rds-ca-2019"), ValidTill: aws.Time(time.Date(2024, 8, 22, 17, 8, 50, 0, time.UTC))},
+		{CertificateIdentifier: aws.String("rds-ca-unknown")},
+		nil,
+	}
+
+	testCases := []struct {
+		Name         string
+		Certificates []*rds.Certificate
+		Expected     string
+	}{
+		{
+			Name: "no certificates",
+		},
+		{
+			Name:         "latest",
+			Certificates: certificates,
+			Expected:     "rds-ca-2019",
+		},
+		{
+			Name:         "no valid till",
+			Certificates: certificates[2:],
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var got string
+			if latest := rdsCertificatesLatestValidTill(testCase.Certificates); len(latest) == 1 {
+				got = aws.StringValue(latest[0].CertificateIdentifier)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestRdsCertificatesCache(t *testing.T) {
+	cache := newRdsCertificatesCache()
+
+	var calls int32
+	fetch := func() ([]*rds.Certificate, error) {
+		atomic.AddInt32(&calls, 1)
+		return []*rds.Certificate{{CertificateIdentifier: aws.String("rds-ca-2019")}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			certificates, err := cache.get(fetch)
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+
+			if len(certificates) != 1 || aws.StringValue(certificates[0].CertificateIdentifier) != "rds-ca-2019" {
+				t.Errorf("unexpected certificates: %v", certificates)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRdsCertificatesCache_Error(t *testing.T) {
+	cache := newRdsCertificatesCache()
+
+	var calls int
+	fetchErr := func() ([]*rds.Certificate, error) {
+		calls++
+		return nil, errors.New("Throttling: Rate exceeded")
+	}
+
+	if _, err := cache.get(fetchErr); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if _, err := cache.get(fetchErr); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if calls != 2 {
+		t.Errorf("expected errors not to be cached (2 calls), got %d", calls)
+	}
+}
+
+func TestAccAWSRdsCertificatesDataSource_basic(t *testing.T) {
+	dataSourceName := "data.aws_rds_certificates.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsCertificatesDataSourceConfigBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(dataSourceName, "certificates.#", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					tfawsresource.TestCheckTypeSetElemAttr(dataSourceName, "ids.*", "rds-ca-2019"),
+					tfawsresource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "certificates.*", map[string]string{
+						"certificate_identifier": "rds-ca-2019",
+						"certificate_type":       "CA",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRdsCertificatesDataSource_latestValidTill(t *testing.T) {
+	dataSourceName := "data.aws_rds_certificates.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsCertificatesDataSourceConfigLatestValidTill,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "certificates.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestMatchResourceAttr(dataSourceName, "certificates.0.valid_till", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccAWSRdsCertificatesDataSourceConfigBasic = `
+data "aws_rds_certificates" "test" {}
+`
+
+const testAccAWSRdsCertificatesDataSourceConfigLatestValidTill = `
+data "aws_rds_certificates" "test" {
+  latest_valid_till = true
+}
+`
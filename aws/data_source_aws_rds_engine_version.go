@@ -0,0 +1,270 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	gversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	rdsEngineVersionUpgradeTypeMajor = "major"
+	rdsEngineVersionUpgradeTypeMinor = "minor"
+)
+
+func dataSourceAwsRdsEngineVersion() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsRdsEngineVersionRead,
+		Schema: map[string]*schema.Schema{
+			"engine": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"engine_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"exportable_log_types": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"latest": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"version"},
+			},
+
+			"major_engine_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"parameter_group_family": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"preferred_upgrade_target": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"preferred_upgrade_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  rdsEngineVersionUpgradeTypeMinor,
+				ValidateFunc: validation.StringInSlice([]string{
+					rdsEngineVersionUpgradeTypeMajor,
+					rdsEngineVersionUpgradeTypeMinor,
+				}, false),
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"supports_log_exports_to_cloudwatch": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"supports_read_replica": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"valid_upgrade_targets": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"version_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsRdsEngineVersionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	input := &rds.DescribeDBEngineVersionsInput{
+		Engine: aws.String(d.Get("engine").(string)),
+	}
+
+	if v, ok := d.GetOk("parameter_group_family"); ok {
+		input.DBParameterGroupFamily = aws.String(v.(string))
+	}
+
+	latest := d.Get("latest").(bool)
+
+	if v, ok := d.GetOk("version"); ok {
+		input.EngineVersion = aws.String(v.(string))
+	} else if !latest {
+		input.DefaultOnly = aws.Bool(true)
+	}
+
+	log.Printf("[DEBUG] Reading RDS engine versions: %v", input)
+	var engineVersions []*rds.DBEngineVersion
+
+	err := conn.DescribeDBEngineVersionsPages(input, func(resp *rds.DescribeDBEngineVersionsOutput, lastPage bool) bool {
+		for _, engineVersion := range resp.DBEngineVersions {
+			if engineVersion == nil {
+				continue
+			}
+
+			engineVersions = append(engineVersions, engineVersion)
+		}
+		return !lastPage
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading RDS engine versions: %w", err)
+	}
+
+	if len(engineVersions) == 0 {
+		return fmt.Errorf("no RDS engine versions found matching criteria; try different search")
+	}
+
+	var found *rds.DBEngineVersion
+
+	if latest {
+		found = rdsEngineVersionLatest(engineVersions)
+	} else if len(engineVersions) > 1 {
+		return fmt.Errorf("multiple RDS engine versions (%d) match the criteria; try a more specific search", len(engineVersions))
+	} else {
+		found = engineVersions[0]
+	}
+
+	d.SetId(aws.StringValue(found.EngineVersion))
+
+	d.Set("engine", found.Engine)
+	d.Set("engine_description", found.DBEngineDescription)
+	d.Set("exportable_log_types", aws.StringValueSlice(found.ExportableLogTypes))
+	d.Set("major_engine_version", rdsEngineMajorVersion(aws.StringValue(found.Engine), aws.StringValue(found.EngineVersion)))
+	d.Set("parameter_group_family", found.DBParameterGroupFamily)
+	d.Set("status", found.Status)
+	d.Set("supports_log_exports_to_cloudwatch", found.SupportsLogExportsToCloudwatchLogs)
+	d.Set("supports_read_replica", found.SupportsReadReplica)
+	d.Set("version", found.EngineVersion)
+	d.Set("version_description", found.DBEngineVersionDescription)
+
+	var upgradeTargets []string
+	for _, upgradeTarget := range found.ValidUpgradeTarget {
+		upgradeTargets = append(upgradeTargets, aws.StringValue(upgradeTarget.EngineVersion))
+	}
+	d.Set("valid_upgrade_targets", upgradeTargets)
+
+	major := d.Get("preferred_upgrade_type").(string) == rdsEngineVersionUpgradeTypeMajor
+	d.Set("preferred_upgrade_target", rdsEngineVersionPreferredUpgradeTarget(found.ValidUpgradeTarget, major))
+
+	return nil
+}
+
+// rdsEngineMajorVersion returns the major engine version of engineVersion as
+// used by option groups, e.g. 5.7 for MySQL 5.7.22, 12 for PostgreSQL 12.3,
+// 9.6 for PostgreSQL 9.6.18, 19 for Oracle 19.0.0.0.ru-2020-07.rur-2020-07.r1
+// and 14.00 for SQL Server 14.00.3281.6.v1. It returns an empty string if the
+// version cannot be parsed.
+func rdsEngineMajorVersion(engine, engineVersion string) string {
+	parts := strings.Split(engineVersion, ".")
+
+	major, err := strconv.Atoi(parts[0])
+
+	if err != nil {
+		return ""
+	}
+
+	// PostgreSQL 10 and Oracle 18 onwards number major versions with a single component.
+	switch {
+	case engine == "postgres" || engine == "aurora-postgresql":
+		if major >= 10 {
+			return parts[0]
+		}
+	case strings.HasPrefix(engine, "oracle"):
+		if major >= 18 {
+			return parts[0]
+		}
+	}
+
+	if len(parts) < 2 {
+		return parts[0]
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
+// rdsEngineVersionPreferredUpgradeTarget returns the newest engine version of
+// the given major or minor version upgrade targets, or an empty string if
+// there is none. Versions which cannot be compared keep the API ordering,
+// which lists upgrade targets from oldest to newest.
+func rdsEngineVersionPreferredUpgradeTarget(upgradeTargets []*rds.UpgradeTarget, major bool) string {
+	var preferred string
+
+	for _, upgradeTarget := range upgradeTargets {
+		if upgradeTarget == nil || aws.BoolValue(upgradeTarget.IsMajorVersionUpgrade) != major {
+			continue
+		}
+
+		engineVersion := aws.StringValue(upgradeTarget.EngineVersion)
+
+		if preferred != "" {
+			preferredVersion, err1 := gversion.NewVersion(preferred)
+			candidateVersion, err2 := gversion.NewVersion(engineVersion)
+
+			if err1 == nil && err2 == nil && candidateVersion.LessThan(preferredVersion) {
+				continue
+			}
+		}
+
+		preferred = engineVersion
+	}
+
+	return preferred
+}
+
+// rdsEngineVersionLatest returns the newest of the given engine versions.
+// Versions which cannot be compared keep the API ordering, which lists engine
+// versions from oldest to newest.
+func rdsEngineVersionLatest(engineVersions []*rds.DBEngineVersion) *rds.DBEngineVersion {
+	var latest *rds.DBEngineVersion
+
+	for _, engineVersion := range engineVersions {
+		if engineVersion == nil {
+			continue
+		}
+
+		if latest != nil {
+			latestVersion, err1 := gversion.NewVersion(aws.StringValue(latest.EngineVersion))
+			candidateVersion, err2 := gversion.NewVersion(aws.StringValue(engineVersion.EngineVersion))
+
+			if err1 == nil && err2 == nil && candidateVersion.LessThan(latestVersion) {
+				continue
+			}
+		}
+
+		latest = engineVersion
+	}
+
+	return latest
+}
@@ -0,0 +1,307 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRdsEngineVersionPreferredUpgradeTarget(t *testing.T) {
+	upgradeTargets := []*rds.UpgradeTarget{
+		{EngineVersion: aws.String("5.7.23"), IsMajorVersionUpgrade: aws.Bool(false)},
+		{EngineVersion: aws.String("5.7.31"), IsMajorVersionUpgrade: aws.Bool(false)},
+		{EngineVersion: aws.String("5.7.28"), IsMajorVersionUpgrade: aws.Bool(false)},
+		{EngineVersion: aws.String("8.0.11"), IsMajorVersionUpgrade: aws.Bool(true)},
+		{EngineVersion: aws.String("8.0.21"), IsMajorVersionUpgrade: aws.Bool(true)},
+		nil,
+	}
+
+	testCases := []struct {
+		Name           string
+		UpgradeTargets []*rds.UpgradeTarget
+		Major          bool
+		Expected       string
+	}{
+		{
+			Name:     "no targets",
+			Expected: "",
+		},
+		{
+			Name:           "minor",
+			UpgradeTargets: upgradeTargets,
+			Major:          false,
+			Expected:       "5.7.31",
+		},
+		{
+			Name:           "major",
+			UpgradeTargets: upgradeTargets,
+			Major:          true,
+			Expected:       "8.0.21",
+		},
+		{
+			Name:           "no major targets",
+			UpgradeTargets: upgradeTargets[:3],
+			Major:          true,
+			Expected:       "",
+		},
+		{
+			Name: "unparsable versions keep API ordering",
+			UpgradeTargets: []*rds.UpgradeTarget{
+				{EngineVersion: aws.String("19.0.0.0.ru-2020-07.rur-2020-07.r1"), IsMajorVersionUpgrade: aws.Bool(false)},
+				{EngineVersion: aws.String("19.0.0.0.ru-2020-10.rur-2020-10.r1"), IsMajorVersionUpgrade: aws.Bool(false)},
+			},
+			Major:    false,
+			Expected: "19.0.0.0.ru-2020-10.rur-2020-10.r1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := rdsEngineVersionPreferredUpgradeTarget(testCase.UpgradeTargets, testCase.Major)
+
+			if got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestRdsEngineVersionLatest(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		EngineVersions []*rds.DBEngineVersion
+		Expected       string
+	}{
+		{
+			Name:     "no versions",
+			Expected: "",
+		},
+		{
+			Name: "newest",
+			EngineVersions: []*rds.DBEngineVersion{
+				{EngineVersion: aws.String("5.7.31")},
+				{EngineVersion: aws.String("8.0.21")},
+				{EngineVersion: aws.String("8.0.11")},
+				nil,
+			},
+			Expected: "8.0.21",
+		},
+		{
+			Name: "numeric rather than lexical ordering",
+			EngineVersions: []*rds.DBEngineVersion{
+				{EngineVersion: aws.String("9.6.20")},
+				{EngineVersion: aws.String("12.4")},
+				{EngineVersion: aws.String("10.14")},
+			},
+			Expected: "12.4",
+		},
+		{
+			Name: "unparsable versions keep API ordering",
+			EngineVersions: []*rds.DBEngineVersion{
+				{EngineVersion: aws.String("19.0.0.0.ru-2020-07.rur-2020-07.r1")},
+				{EngineVersion: aws.String("19.0.0.0.ru-2020-10.rur-2020-10.r1")},
+			},
+			Expected: "19.0.0.0.ru-2020-10.rur-2020-10.r1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var got string
+			if latest := rdsEngineVersionLatest(testCase.EngineVersions); latest != nil {
+				got = aws.StringValue(latest.EngineVersion)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestRdsEngineMajorVersion(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Engine        string
+		EngineVersion string
+		Expected      string
+	}{
+		{
+			Name:          "mysql",
+			Engine:        "mysql",
+			EngineVersion: "5.7.22",
+			Expected:      "5.7",
+		},
+		{
+			Name:          "mariadb",
+			Engine:        "mariadb",
+			EngineVersion: "10.4.13",
+			Expected:      "10.4",
+		},
+		{
+			Name:          "postgres 9",
+			Engine:        "postgres",
+			EngineVersion: "9.6.18",
+			Expected:      "9.6",
+		},
+		{
+			Name:          "postgres 12",
+			Engine:        "postgres",
+			EngineVersion: "12.3",
+			Expected:      "12",
+		},
+		{
+			Name:          "oracle 12",
+			Engine:        "oracle-ee",
+			EngineVersion: "12.1.0.2.v21",
+			Expected:      "12.1",
+		},
+		{
+			Name:          "oracle 19",
+			Engine:        "oracle-se2",
+			EngineVersion: "19.0.0.0.ru-2020-07.rur-2020-07.r1",
+			Expected:      "19",
+		},
+		{
+			Name:          "sqlserver",
+			Engine:        "sqlserver-ex",
+			EngineVersion: "14.00.3281.6.v1",
+			Expected:      "14.00",
+		},
+		{
+			Name:          "unparsable",
+			Engine:        "mysql",
+			EngineVersion: "",
+			Expected:      "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got := rdsEngineMajorVersion(testCase.Engine, testCase.EngineVersion)
+
+			if got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestAccAWSRdsEngineVersionDataSource_basic(t *testing.T) {
+	dataSourceName := "data.aws_rds_engine_version.test"
+	engine := "mysql"
+	version := "5.7.22"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsEngineVersion(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsEngineVersionDataSourceConfigBasic(engine, version, "minor"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "engine", engine),
+					resource.TestCheckResourceAttr(dataSourceName, "version", version),
+					resource.TestCheckResourceAttr(dataSourceName, "parameter_group_family", "mysql5.7"),
+					resource.TestCheckResourceAttr(dataSourceName, "major_engine_version", "5.7"),
+					resource.TestMatchResourceAttr(dataSourceName, "valid_upgrade_targets.#", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestMatchResourceAttr(dataSourceName, "preferred_upgrade_target", regexp.MustCompile(`^5\.7\.`)),
+				),
+			},
+			{
+				Config: testAccAWSRdsEngineVersionDataSourceConfigBasic(engine, version, "major"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(dataSourceName, "preferred_upgrade_target", regexp.MustCompile(`^8\.0\.`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRdsEngineVersionDataSource_defaultOnly(t *testing.T) {
+	dataSourceName := "data.aws_rds_engine_version.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsEngineVersion(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsEngineVersionDataSourceConfigDefaultOnly("mysql"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "engine", "mysql"),
+					resource.TestMatchResourceAttr(dataSourceName, "version", regexp.MustCompile(`^\d+\.\d+\.\d+$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRdsEngineVersionDataSource_latest(t *testing.T) {
+	dataSourceName := "data.aws_rds_engine_version.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsEngineVersion(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsEngineVersionDataSourceConfigLatest("mysql"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "engine", "mysql"),
+					resource.TestMatchResourceAttr(dataSourceName, "version", regexp.MustCompile(`^\d+\.\d+\.\d+$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccPreCheckAWSRdsEngineVersion(t *testing.T) {
+	conn := testAccProvider.Meta().(*AWSClient).rdsconn
+
+	input := &rds.DescribeDBEngineVersionsInput{
+		Engine:      aws.String("mysql"),
+		DefaultOnly: aws.Bool(true),
+	}
+
+	_, err := conn.DescribeDBEngineVersions(input)
+
+	if testAccPreCheckSkipError(err) {
+		t.Skipf("skipping acceptance testing: %s", err)
+	}
+
+	if err != nil {
+		t.Fatalf("unexpected PreCheck error: %s", err)
+	}
+}
+
+func testAccAWSRdsEngineVersionDataSourceConfigBasic(engine, version, upgradeType string) string {
+	return fmt.Sprintf(`
+data "aws_rds_engine_version" "test" {
+  engine                 = %q
+  version                = %q
+  preferred_upgrade_type = %q
+}
+`, engine, version, upgradeType)
+}
+
+func testAccAWSRdsEngineVersionDataSourceConfigDefaultOnly(engine string) string {
+	return fmt.Sprintf(`
+data "aws_rds_engine_version" "test" {
+  engine = %q
+}
+`, engine)
+}
+
+func testAccAWSRdsEngineVersionDataSourceConfigLatest(engine string) string {
+	return fmt.Sprintf(`
+data "aws_rds_engine_version" "test" {
+  engine = %q
+  latest = true
+}
+`, engine)
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"fmt"
 	"log"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
@@ -181,38 +182,55 @@ func dataSourceAwsRdsOrderableDbInstanceRead(d *schema.ResourceData, meta interf
 		input.EngineVersion = aws.String(v.(string))
 	}
 
-	if v, ok := d.GetOk("license_model"); ok {
-		input.LicenseModel = aws.String(v.(string))
+	licenseModel, err := rdsOrderableDbInstanceLicenseModel(d.Get("engine").(string), d.Get("license_model").(string))
+
+	if err != nil {
+		return err
+	}
+
+	if licenseModel != "" {
+		input.LicenseModel = aws.String(licenseModel)
 	}
 
-	if v, ok := d.GetOk("vpc"); ok {
+	// Filtering on vpc = false returns only EC2-Classic offerings.
+	if v, ok := d.GetOkExists("vpc"); ok {
 		input.Vpc = aws.Bool(v.(bool))
 	}
 
 	log.Printf("[DEBUG] Reading RDS Orderable DB Instance Options: %v", input)
-	var instanceClassResults []*rds.OrderableDBInstanceOption
+	instanceOptions, err := meta.(*AWSClient).rdsOrderableDbInstanceOptionsCache.get(input, func() ([]*rds.OrderableDBInstanceOption, error) {
+		var instanceOptions []*rds.OrderableDBInstanceOption
 
-	err := conn.DescribeOrderableDBInstanceOptionsPages(input, func(resp *rds.DescribeOrderableDBInstanceOptionsOutput, lastPage bool) bool {
-		for _, instanceOption := range resp.OrderableDBInstanceOptions {
-			if instanceOption == nil {
-				continue
-			}
-
-			if v, ok := d.GetOk("storage_type"); ok {
-				if aws.StringValue(instanceOption.StorageType) != v.(string) {
+		err := conn.DescribeOrderableDBInstanceOptionsPages(input, func(resp *rds.DescribeOrderableDBInstanceOptionsOutput, lastPage bool) bool {
+			for _, instanceOption := range resp.OrderableDBInstanceOptions {
+				if instanceOption == nil {
 					continue
 				}
+
+				instanceOptions = append(instanceOptions, instanceOption)
 			}
+			return !lastPage
+		})
 
-			instanceClassResults = append(instanceClassResults, instanceOption)
-		}
-		return !lastPage
+		return instanceOptions, err
 	})
 
 	if err != nil {
 		return fmt.Errorf("error reading RDS orderable DB instance options: %w", err)
 	}
 
+	var instanceClassResults []*rds.OrderableDBInstanceOption
+
+	for _, instanceOption := range instanceOptions {
+		if v, ok := d.GetOk("storage_type"); ok {
+			if aws.StringValue(instanceOption.StorageType) != v.(string) {
+				continue
+			}
+		}
+
+		instanceClassResults = append(instanceClassResults, instanceOption)
+	}
+
 	if len(instanceClassResults) == 0 {
 		return fmt.Errorf("no RDS Orderable DB Instance options found matching criteria; try different search")
 	}
@@ -289,3 +307,92 @@ func dataSourceAwsRdsOrderableDbInstanceRead(d *schema.ResourceData, meta interf
 
 	return nil
 }
+
+// rdsEngineLicenseModels lists the license models supported by engines which do
+// not support every license model. The first license model is the default.
+var rdsEngineLicenseModels = map[string][]string{
+	"mariadb":       {"general-public-license"},
+	"mysql":         {"general-public-license"},
+	"oracle-ee":     {"bring-your-own-license"},
+	"oracle-se2":    {"license-included", "bring-your-own-license"},
+	"postgres":      {"postgresql-license"},
+	"sqlserver-ee":  {"license-included"},
+	"sqlserver-ex":  {"license-included"},
+	"sqlserver-se":  {"license-included"},
+	"sqlserver-web": {"license-included"},
+}
+
+// rdsOrderableDbInstanceLicenseModel returns the license model to search for,
+// defaulting it for known engines and rejecting license models an engine does
+// not support, which would otherwise only fail when creating the DB instance.
+func rdsOrderableDbInstanceLicenseModel(engine, licenseModel string) (string, error) {
+	licenseModels, ok := rdsEngineLicenseModels[engine]
+
+	if !ok {
+		return licenseModel, nil
+	}
+
+	if licenseModel == "" {
+		return licenseModels[0], nil
+	}
+
+	for _, v := range licenseModels {
+		if v == licenseModel {
+			return licenseModel, nil
+		}
+	}
+
+	return "", fmt.Errorf("license_model (%s) is not supported for engine (%s), expected one of %q", licenseModel, engine, licenseModels)
+}
+
+// rdsOrderableDbInstanceOptionsCache memoizes DescribeOrderableDBInstanceOptions
+// results for the lifetime of the provider, as large configurations can read the
+// data source many times with the same arguments. Concurrent reads with the same
+// input share a single API call. Errors are not cached.
+type rdsOrderableDbInstanceOptionsCache struct {
+	mu      sync.Mutex
+	entries map[string]*rdsOrderableDbInstanceOptionsCacheEntry
+}
+
+type rdsOrderableDbInstanceOptionsCacheEntry struct {
+	once            sync.Once
+	instanceOptions []*rds.OrderableDBInstanceOption
+	err             error
+}
+
+func newRdsOrderableDbInstanceOptionsCache() *rdsOrderableDbInstanceOptionsCache {
+	return &rdsOrderableDbInstanceOptionsCache{
+		entries: make(map[string]*rdsOrderableDbInstanceOptionsCacheEntry),
+	}
+}
+
+// get returns the cached options for the input, calling fetch to populate them.
+func (c *rdsOrderableDbInstanceOptionsCache) get(input *rds.DescribeOrderableDBInstanceOptionsInput, fetch func() ([]*rds.OrderableDBInstanceOption, error)) ([]*rds.OrderableDBInstanceOption, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	key := input.String()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &rdsOrderableDbInstanceOptionsCacheEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.instanceOptions, entry.err = fetch()
+	})
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+
+	return entry.instanceOptions, entry.err
+}
@@ -1,7 +1,11 @@
 package aws
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -9,6 +13,137 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+func TestRdsOrderableDbInstanceOptionsCache(t *testing.T) {
+	cache := newRdsOrderableDbInstanceOptionsCache()
+
+	var calls int32
+	fetch := func() ([]*rds.OrderableDBInstanceOption, error) {
+		atomic.AddInt32(&calls, 1)
+		return []*rds.OrderableDBInstanceOption{{DBInstanceClass: aws.String("db.t3.micro")}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			input := &rds.DescribeOrderableDBInstanceOptionsInput{
+				Engine:        aws.String("mysql"),
+				EngineVersion: aws.String("5.7.22"),
+			}
+
+			instanceOptions, err := cache.get(input, fetch)
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+
+			if len(instanceOptions) != 1 || aws.StringValue(instanceOptions[0].DBInstanceClass) != "db.t3.micro" {
+				t.Errorf("unexpected instance options: %v", instanceOptions)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call for identical inputs, got %d", got)
+	}
+
+	if _, err := cache.get(&rds.DescribeOrderableDBInstanceOptionsInput{Engine: aws.String("postgres")}, fetch); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 calls for different inputs, got %d", got)
+	}
+}
+
+func TestRdsOrderableDbInstanceOptionsCache_Error(t *testing.T) {
+	cache := newRdsOrderableDbInstanceOptionsCache()
+	input := &rds.DescribeOrderableDBInstanceOptionsInput{Engine: aws.String("mysql")}
+
+	var calls int
+	fetchErr := func() ([]*rds.OrderableDBInstanceOption, error) {
+		calls++
+		return nil, errors.New("Throttling: Rate exceeded")
+	}
+
+	if _, err := cache.get(input, fetchErr); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if _, err := cache.get(input, fetchErr); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if calls != 2 {
+		t.Errorf("expected errors not to be cached (2 calls), got %d", calls)
+	}
+}
+
+func TestRdsOrderableDbInstanceLicenseModel(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Engine       string
+		LicenseModel string
+		Expected     string
+		ExpectError  bool
+	}{
+		{
+			Name:     "sqlserver default",
+			Engine:   "sqlserver-ex",
+			Expected: "license-included",
+		},
+		{
+			Name:     "oracle-ee default",
+			Engine:   "oracle-ee",
+			Expected: "bring-your-own-license",
+		},
+		{
+			Name:         "oracle-se2 bring your own license",
+			Engine:       "oracle-se2",
+			LicenseModel: "bring-your-own-license",
+			Expected:     "bring-your-own-license",
+		},
+		{
+			Name:         "mysql unsupported",
+			Engine:       "mysql",
+			LicenseModel: "license-included",
+			ExpectError:  true,
+		},
+		{
+			Name:   "unknown engine",
+			Engine: "aurora-mysql",
+		},
+		{
+			Name:         "unknown engine configured",
+			Engine:       "aurora-mysql",
+			LicenseModel: "general-public-license",
+			Expected:     "general-public-license",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := rdsOrderableDbInstanceLicenseModel(tc.Engine, tc.LicenseModel)
+
+			if tc.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tc.Expected {
+				t.Errorf("got %q, expected %q", got, tc.Expected)
+			}
+		})
+	}
+}
+
 func TestAccAWSRdsOrderableDbInstanceDataSource_basic(t *testing.T) {
 	dataSourceName := "data.aws_rds_orderable_db_instance.test"
 	class := "db.t2.small"
@@ -30,6 +165,7 @@ func TestAccAWSRdsOrderableDbInstanceDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(dataSourceName, "engine_version", engineVersion),
 					resource.TestCheckResourceAttr(dataSourceName, "license_model", licenseModel),
 					resource.TestCheckResourceAttr(dataSourceName, "storage_type", storageType),
+					resource.TestMatchResourceAttr(dataSourceName, "availability_zones.#", regexp.MustCompile(`^[1-9][0-9]*$`)),
 				),
 			},
 		},
@@ -63,6 +199,81 @@ func TestAccAWSRdsOrderableDbInstanceDataSource_preferred(t *testing.T) {
 	})
 }
 
+func TestAccAWSRdsOrderableDbInstanceDataSource_storageBounds(t *testing.T) {
+	dataSourceName := "data.aws_rds_orderable_db_instance.test"
+	class := "db.m5.large"
+	engine := "mysql"
+	engineVersion := "5.7.22"
+	licenseModel := "general-public-license"
+	storageType := "io1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsOrderableDbInstance(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsOrderableDbInstanceDataSourceConfigBasic(class, engine, engineVersion, licenseModel, storageType),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "storage_type", storageType),
+					resource.TestCheckResourceAttr(dataSourceName, "supports_iops", "true"),
+					resource.TestMatchResourceAttr(dataSourceName, "min_storage_size", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestMatchResourceAttr(dataSourceName, "max_storage_size", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestMatchResourceAttr(dataSourceName, "min_iops_per_db_instance", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestMatchResourceAttr(dataSourceName, "max_iops_per_db_instance", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestCheckResourceAttrSet(dataSourceName, "min_iops_per_gib"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "max_iops_per_gib"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRdsOrderableDbInstanceDataSource_licenseModelDefault(t *testing.T) {
+	dataSourceName := "data.aws_rds_orderable_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsOrderableDbInstance(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsOrderableDbInstanceDataSourceConfigLicenseModelDefault(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "engine", "sqlserver-ex"),
+					resource.TestCheckResourceAttr(dataSourceName, "license_model", "license-included"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "db_instance_class"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSRdsOrderableDbInstanceDataSource_vpc(t *testing.T) {
+	dataSourceName := "data.aws_rds_orderable_db_instance.test"
+	engine := "mysql"
+	engineVersion := "5.7.22"
+	licenseModel := "general-public-license"
+	storageType := "standard"
+	preferredOption := "db.t2.small"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsOrderableDbInstance(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSRdsOrderableDbInstanceDataSourceConfigVpc(engine, engineVersion, licenseModel, storageType, preferredOption, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "engine", engine),
+					resource.TestCheckResourceAttr(dataSourceName, "db_instance_class", preferredOption),
+					resource.TestCheckResourceAttr(dataSourceName, "vpc", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccPreCheckAWSRdsOrderableDbInstance(t *testing.T) {
 	conn := testAccProvider.Meta().(*AWSClient).rdsconn
 
@@ -105,3 +316,32 @@ data "aws_rds_orderable_db_instance" "test" {
 }
 `, engine, version, license, storage, preferredOption)
 }
+
+func testAccAWSRdsOrderableDbInstanceDataSourceConfigVpc(engine, version, license, storage, preferredOption string, vpc bool) string {
+	return fmt.Sprintf(`
+data "aws_rds_orderable_db_instance" "test" {
+  engine         = %q
+  engine_version = %q
+  license_model  = %q
+  storage_type   = %q
+  vpc            = %t
+
+  preferred_db_instance_classes = ["db.xyz.xlarge", %q, "db.t3.small"]
+}
+`, engine, version, license, storage, vpc, preferredOption)
+}
+
+func testAccAWSRdsOrderableDbInstanceDataSourceConfigLicenseModelDefault() string {
+	return `
+data "aws_rds_engine_version" "test" {
+  engine = "sqlserver-ex"
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                        = data.aws_rds_engine_version.test.engine
+  engine_version                = data.aws_rds_engine_version.test.version
+  preferred_db_instance_classes = ["db.t3.small", "db.t2.small", "db.t3.medium"]
+  storage_type                  = "gp2"
+}
+`
+}
@@ -23,12 +23,27 @@ const (
 	RdsTagKeyPrefix              = `rds:`
 )
 
+// DefaultConfig contains tags to default across all resources.
+type DefaultConfig struct {
+	Tags KeyValueTags
+}
+
 // IgnoreConfig contains various options for removing resource tags.
 type IgnoreConfig struct {
 	Keys        KeyValueTags
 	KeyPrefixes KeyValueTags
 }
 
+// MergeTags returns the default tags merged with the given resource tags,
+// with resource tags taking precedence for matching keys.
+func (dc *DefaultConfig) MergeTags(tags KeyValueTags) KeyValueTags {
+	if dc == nil || dc.Tags == nil {
+		return tags
+	}
+
+	return dc.Tags.Merge(tags)
+}
+
 // KeyValueTags is a standard implementation for AWS key-value resource tags.
 // The AWS Go SDK is split into multiple service packages, each service with
 // its own Go struct type representing a resource tag. To standardize logic
@@ -48,6 +63,24 @@ func (tags KeyValueTags) IgnoreAws() KeyValueTags {
 	return result
 }
 
+// RemoveDefaultConfig returns tags not matching a default tag key and value
+// of the given configuration.
+func (tags KeyValueTags) RemoveDefaultConfig(config *DefaultConfig) KeyValueTags {
+	if config == nil || config.Tags == nil {
+		return tags
+	}
+
+	result := make(KeyValueTags)
+
+	for k, v := range tags {
+		if defaultV, ok := config.Tags[k]; !ok || !v.Equal(defaultV) {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
 // IgnoreConfig returns any tags not removed by a given configuration.
 func (tags KeyValueTags) IgnoreConfig(config *IgnoreConfig) KeyValueTags {
 	if config == nil {
@@ -669,6 +669,19 @@ provider "awsthird" {
 	return config.String()
 }
 
+func testAccProviderConfigDefaultTags(key1, value1 string) string {
+	//lintignore:AT004
+	return fmt.Sprintf(`
+provider "aws" {
+  default_tags {
+    tags = {
+      %[1]q = %[2]q
+    }
+  }
+}
+`, key1, value1)
+}
+
 func testAccProviderConfigIgnoreTagsKeyPrefixes1(keyPrefix1 string) string {
 	//lintignore:AT004
 	return fmt.Sprintf(`
@@ -1,19 +1,28 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/rds"
+	gversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	iamwaiter "github.com/terraform-providers/terraform-provider-aws/aws/internal/service/iam/waiter"
 )
 
 func resourceAwsDbInstance() *schema.Resource {
@@ -42,11 +51,20 @@ func resourceAwsDbInstance() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"db_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+			},
+
 			"name": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"db_name"},
 			},
 
 			"arn": {
@@ -81,6 +99,7 @@ func resourceAwsDbInstance() *schema.Resource {
 					value := v.(string)
 					return strings.ToLower(value)
 				},
+				ValidateFunc: validateDbInstanceEngine,
 			},
 
 			"engine_version": {
@@ -96,6 +115,23 @@ func resourceAwsDbInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"certificate_details": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ca_identifier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"valid_till": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"character_set_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -104,9 +140,11 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"storage_encrypted": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				ForceNew: true,
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressDbInstanceReplicaInheritedEncryptionDiff,
 			},
 
 			"allocated_storage": {
@@ -148,7 +186,6 @@ func resourceAwsDbInstance() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Computed:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"identifier_prefix"},
 				ValidateFunc:  validateRdsIdentifier,
 			},
@@ -166,10 +203,11 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"availability_zone": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressDbInstanceMultiAzAvailabilityZoneDiff,
 			},
 
 			"backup_retention_period": {
@@ -179,10 +217,12 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"backup_window": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ValidateFunc: validateOnceADayWindowFormat,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				StateFunc:        normalizeDbInstanceWindow,
+				DiffSuppressFunc: suppressEquivalentDbInstanceWindow,
+				ValidateFunc:     validateDbInstanceBackupWindow,
 			},
 
 			"iops": {
@@ -197,27 +237,19 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"maintenance_window": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				StateFunc: func(v interface{}) string {
-					if v != nil {
-						value := v.(string)
-						return strings.ToLower(value)
-					}
-					return ""
-				},
-				ValidateFunc: validateOnceAWeekWindowFormat,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				StateFunc:        normalizeDbInstanceWindow,
+				DiffSuppressFunc: suppressEquivalentDbInstanceWindow,
+				ValidateFunc:     validateDbInstanceMaintenanceWindow,
 			},
 
 			"max_allocated_storage": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					if old == "0" && new == fmt.Sprintf("%d", d.Get("allocated_storage").(int)) {
-						return true
-					}
-					return false
+					return dbInstanceMaxAllocatedStorageDisabledEquivalent(old, new, d.Get("allocated_storage").(int))
 				},
 			},
 
@@ -233,6 +265,11 @@ func resourceAwsDbInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"db_instance_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
 			"publicly_accessible": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -273,11 +310,52 @@ func resourceAwsDbInstance() *schema.Resource {
 				},
 			},
 
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"restore_to_point_in_time": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				ConflictsWith: []string{
+					"s3_import",
+					"snapshot_identifier",
+					"replicate_source_db",
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"restore_time": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ForceNew:      true,
+							ValidateFunc:  validation.IsRFC3339Time,
+							ConflictsWith: []string{"restore_to_point_in_time.0.use_latest_restorable_time"},
+						},
+						"source_db_instance_identifier": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"use_latest_restorable_time": {
+							Type:          schema.TypeBool,
+							Optional:      true,
+							ForceNew:      true,
+							ConflictsWith: []string{"restore_to_point_in_time.0.restore_time"},
+						},
+					},
+				},
+			},
+
 			"s3_import": {
 				Type:     schema.TypeList,
 				Optional: true,
 				MaxItems: 1,
 				ConflictsWith: []string{
+					"restore_to_point_in_time",
 					"snapshot_identifier",
 					"replicate_source_db",
 				},
@@ -362,12 +440,60 @@ func resourceAwsDbInstance() *schema.Resource {
 			"apply_immediately": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Default:  false,
+			},
+
+			// pending_modified_values exposes changes which have been queued
+			// for the next maintenance window when apply_immediately is false.
+			"pending_modified_values": {
+				Type:     schema.TypeList,
 				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allocated_storage": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"backup_retention_period": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ca_cert_identifier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"engine_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"iops": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"multi_az": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"storage_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 
 			"replicate_source_db": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentRdsSourceDbIdentifierAndARN,
 			},
 
 			"replicas": {
@@ -376,6 +502,15 @@ func resourceAwsDbInstance() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			// replica_mode is only supported by Oracle replicas and is empty
+			// for other engines, so it is computed to avoid a difference.
+			"replica_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(rds.ReplicaMode_Values(), false),
+			},
+
 			"snapshot_identifier": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -391,12 +526,21 @@ func resourceAwsDbInstance() *schema.Resource {
 			"allow_major_version_upgrade": {
 				Type:     schema.TypeBool,
 				Optional: true,
+				Default:  false,
+			},
+
+			"create_monitoring_role": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"monitoring_role_arn"},
 			},
 
 			"monitoring_role_arn": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"create_monitoring_role"},
+				DiffSuppressFunc: suppressDbInstanceMonitoringRoleArnOmittedDiff,
 			},
 
 			"monitoring_interval": {
@@ -406,17 +550,23 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"option_group_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressDbInstanceDefaultOptionGroupDiff,
+			},
+
+			"option_group_status": {
 				Type:     schema.TypeString,
-				Optional: true,
 				Computed: true,
 			},
 
 			"kms_key_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Computed:     true,
-				ForceNew:     true,
-				ValidateFunc: validateArn,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateArn,
+				DiffSuppressFunc: suppressDbInstanceReplicaInheritedEncryptionDiff,
 			},
 
 			"timezone": {
@@ -437,8 +587,9 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"enabled_cloudwatch_logs_exports": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
+				Set:      schema.HashString,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 					ValidateFunc: validation.StringInSlice([]string{
@@ -464,6 +615,10 @@ func resourceAwsDbInstance() *schema.Resource {
 			"domain_iam_role_name": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 64),
+					validation.StringMatch(regexp.MustCompile(`^[\w+=,.@-]*$`), "must be an IAM role name, not an ARN, and match [\\w+=,.@-]"),
+				),
 			},
 
 			"performance_insights_enabled": {
@@ -480,9 +635,10 @@ func resourceAwsDbInstance() *schema.Resource {
 			},
 
 			"performance_insights_retention_period": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressDbInstancePerformanceInsightsDisabledDiff,
 			},
 
 			"delete_automated_backups": {
@@ -491,8 +647,270 @@ func resourceAwsDbInstance() *schema.Resource {
 				Default:  true,
 			},
 
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsSchemaTrulyComputed(),
 		},
+
+		CustomizeDiff: customdiff.Sequence(
+			setTagsDiff,
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// Plan time validation for enabled_cloudwatch_logs_exports
+				// InvalidParameterCombination: You cannot use the log types 'audit' with engine postgres.
+				// The engine of a replica is computed from its source and is
+				// not known at plan time, so validation is left to the API.
+				if !diff.NewValueKnown("engine") {
+					return nil
+				}
+				engine := strings.ToLower(diff.Get("engine").(string))
+				logTypes := expandStringSet(diff.Get("enabled_cloudwatch_logs_exports").(*schema.Set))
+				return validateDbInstanceCloudwatchLogsExports(engine, aws.StringValueSlice(logTypes))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// Plan time validation for SQL Server Multi-AZ (mirroring)
+				// InvalidParameterValue: Mirroring cannot be applied to instances with backup retention set to zero.
+				// Replicas and values only known after apply are left for the API to validate.
+				if _, ok := diff.GetOk("replicate_source_db"); ok {
+					return nil
+				}
+				if !diff.NewValueKnown("engine") || !diff.NewValueKnown("backup_retention_period") {
+					return nil
+				}
+				engine := strings.ToLower(diff.Get("engine").(string))
+				return validateDbInstanceSqlServerMultiAz(engine, diff.Get("multi_az").(bool), diff.Get("backup_retention_period").(int))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// Replicas and restores may inherit their storage, so values only
+				// known after apply are left for the API to validate.
+				if !diff.NewValueKnown("iops") {
+					return nil
+				}
+				var engine, storageType string
+				var allocatedStorage int
+				if diff.NewValueKnown("engine") {
+					engine = strings.ToLower(diff.Get("engine").(string))
+				}
+				if diff.NewValueKnown("storage_type") {
+					storageType = diff.Get("storage_type").(string)
+				}
+				if diff.NewValueKnown("allocated_storage") {
+					allocatedStorage = diff.Get("allocated_storage").(int)
+				}
+				_, replica := diff.GetOk("replicate_source_db")
+				_, snapshot := diff.GetOk("snapshot_identifier")
+				_, pointInTime := diff.GetOk("restore_to_point_in_time")
+				return validateDbInstanceStorage(engine, storageType, allocatedStorage, diff.Get("iops").(int), replica || snapshot || pointInTime)
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				if !diff.NewValueKnown("port") {
+					return nil
+				}
+				// An engine only known after apply still has its port range checked.
+				var engine string
+				if diff.NewValueKnown("engine") {
+					engine = strings.ToLower(diff.Get("engine").(string))
+				}
+				return validateDbInstancePort(engine, diff.Get("port").(int))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// Replicas and restores can inherit the engine and storage.
+				if diff.Id() != "" && !diff.HasChange("allocated_storage") {
+					return nil
+				}
+				if !diff.NewValueKnown("engine") || !diff.NewValueKnown("allocated_storage") {
+					return nil
+				}
+				engine := strings.ToLower(diff.Get("engine").(string))
+				return validateDbInstanceAllocatedStorage(engine, diff.Get("allocated_storage").(int))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// Replicas and restores inherit the username, and existing
+				// instances are only checked when it changes.
+				if diff.Id() != "" && !diff.HasChange("username") {
+					return nil
+				}
+				if !diff.NewValueKnown("engine") || !diff.NewValueKnown("username") {
+					return nil
+				}
+				engine := strings.ToLower(diff.Get("engine").(string))
+				return validateDbInstanceUsername(engine, diff.Get("username").(string))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// Replicas whose engine is inherited from the source are left for
+				// the API to validate.
+				if _, ok := diff.GetOk("replicate_source_db"); !ok {
+					return nil
+				}
+				if !diff.NewValueKnown("engine") || !diff.NewValueKnown("multi_az") {
+					return nil
+				}
+				engine := strings.ToLower(diff.Get("engine").(string))
+				return validateDbInstanceReplicaMultiAz(engine, diff.Get("multi_az").(bool))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				if _, ok := diff.GetOk("replicate_source_db"); !ok {
+					return nil
+				}
+				if diff.Id() != "" && !diff.HasChange("backup_retention_period") {
+					return nil
+				}
+				if !diff.NewValueKnown("engine") || !diff.NewValueKnown("backup_retention_period") {
+					return nil
+				}
+				engine := strings.ToLower(diff.Get("engine").(string))
+				return validateDbInstanceReplicaBackupRetentionPeriod(engine, diff.Get("backup_retention_period").(int))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// RDS does not support downgrading the engine version and rejects it
+				// with an unclear error, so catch it at plan time.
+				if diff.Id() == "" || !diff.HasChange("engine_version") || !diff.NewValueKnown("engine_version") {
+					return nil
+				}
+				o, n := diff.GetChange("engine_version")
+				return validateDbInstanceEngineVersionUpgrade(o.(string), n.(string))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// Encryption cannot be changed on an existing DB instance and
+				// replacing it would discard its data, so require a migration path.
+				if diff.Id() == "" || !diff.HasChange("storage_encrypted") {
+					return nil
+				}
+				o, n := diff.GetChange("storage_encrypted")
+				if !o.(bool) && n.(bool) {
+					return fmt.Errorf("storage_encrypted cannot be enabled on existing unencrypted DB Instance (%s): copy a DB snapshot of it with encryption enabled and restore a new DB instance from that copy using snapshot_identifier", diff.Id())
+				}
+				if o.(bool) && !n.(bool) {
+					return fmt.Errorf("storage_encrypted cannot be disabled on existing encrypted DB Instance (%s): an encrypted DB snapshot cannot be restored unencrypted, so export the data (e.g. with the engine's native tools) into a new unencrypted DB instance", diff.Id())
+				}
+				return nil
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// InvalidParameterCombination: A MonitoringRoleARN value is required if you specify a MonitoringInterval value other than 0.
+				// Roles only known after apply, e.g. created in the same configuration, are skipped.
+				if !diff.NewValueKnown("monitoring_interval") || !diff.NewValueKnown("create_monitoring_role") || !diff.NewValueKnown("monitoring_role_arn") {
+					return nil
+				}
+				monitoringRoleArn := diff.Get("monitoring_role_arn").(string)
+				// A role created by create_monitoring_role is deleted when disabling it.
+				if o, _ := diff.GetChange("create_monitoring_role"); o.(bool) && !diff.HasChange("monitoring_role_arn") {
+					monitoringRoleArn = ""
+				}
+				return validateDbInstanceMonitoringRoleArnRequired(diff.Get("monitoring_interval").(int), monitoringRoleArn, diff.Get("create_monitoring_role").(bool))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				// RDS keeps the key when Performance Insights is disabled, so
+				// only a newly configured key is checked.
+				if !diff.NewValueKnown("performance_insights_enabled") || !diff.NewValueKnown("performance_insights_kms_key_id") {
+					return nil
+				}
+				if diff.Id() != "" && !diff.HasChange("performance_insights_kms_key_id") {
+					return nil
+				}
+				return validateDbInstancePerformanceInsightsKmsKeyId(diff.Get("performance_insights_enabled").(bool), diff.Get("performance_insights_kms_key_id").(string))
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				// Performance Insights is not supported by every engine and
+				// instance class, which the API otherwise only reports on apply.
+				if !diff.Get("performance_insights_enabled").(bool) {
+					return nil
+				}
+				if diff.Id() != "" && !diff.HasChange("performance_insights_enabled") && !diff.HasChange("instance_class") && !diff.HasChange("engine_version") {
+					return nil
+				}
+				if !diff.NewValueKnown("engine") || !diff.NewValueKnown("instance_class") {
+					return nil
+				}
+
+				engine := strings.ToLower(diff.Get("engine").(string))
+				instanceClass := diff.Get("instance_class").(string)
+
+				// The default engine version is only known after creation.
+				var engineVersion string
+				if diff.NewValueKnown("engine_version") {
+					engineVersion = diff.Get("engine_version").(string)
+				}
+
+				// Replicas and restores can inherit the engine.
+				if engine == "" || instanceClass == "" {
+					return nil
+				}
+
+				input := &rds.DescribeOrderableDBInstanceOptionsInput{
+					DBInstanceClass: aws.String(instanceClass),
+					Engine:          aws.String(engine),
+				}
+
+				conn := meta.(*AWSClient).rdsconn
+				instanceOptions, err := meta.(*AWSClient).rdsOrderableDbInstanceOptionsCache.get(input, func() ([]*rds.OrderableDBInstanceOption, error) {
+					var instanceOptions []*rds.OrderableDBInstanceOption
+
+					err := conn.DescribeOrderableDBInstanceOptionsPages(input, func(page *rds.DescribeOrderableDBInstanceOptionsOutput, lastPage bool) bool {
+						instanceOptions = append(instanceOptions, page.OrderableDBInstanceOptions...)
+						return !lastPage
+					})
+
+					return instanceOptions, err
+				})
+
+				if err != nil {
+					log.Printf("[WARN] Unable to validate DB Instance Performance Insights support: error reading RDS orderable DB instance options: %s", err)
+					return nil
+				}
+
+				return validateDbInstancePerformanceInsights(instanceOptions, engine, engineVersion, instanceClass)
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				// Referencing a group or CA certificate that does not exist
+				// otherwise only fails once the DB instance is being created or
+				// modified. Names only known after apply, e.g. of groups created in
+				// the same configuration, are skipped.
+				conn := meta.(*AWSClient).rdsconn
+				region := meta.(*AWSClient).region
+
+				if (diff.HasChange("availability_zone") || diff.HasChange("db_subnet_group_name")) && diff.NewValueKnown("availability_zone") && diff.NewValueKnown("db_subnet_group_name") {
+					availabilityZone := diff.Get("availability_zone").(string)
+					dbSubnetGroupName := diff.Get("db_subnet_group_name").(string)
+
+					if availabilityZone != "" && dbSubnetGroupName != "" {
+						if err := validateDbInstanceAvailabilityZone(conn, availabilityZone, dbSubnetGroupName); err != nil {
+							return err
+						}
+					}
+				}
+
+				if diff.HasChange("ca_cert_identifier") && diff.NewValueKnown("ca_cert_identifier") {
+					if v := diff.Get("ca_cert_identifier").(string); v != "" {
+						if err := validateDbInstanceCACertificateIdentifier(conn, meta.(*AWSClient).rdsCertificatesCache, region, v); err != nil {
+							return err
+						}
+					}
+				}
+
+				if diff.HasChange("parameter_group_name") && diff.NewValueKnown("parameter_group_name") {
+					if v := diff.Get("parameter_group_name").(string); v != "" {
+						if err := validateDbInstanceParameterGroupExists(conn, region, v); err != nil {
+							return err
+						}
+					}
+				}
+
+				// The option group must also match the major version of a new
+				// engine_version. Engine default option groups are switched by RDS.
+				if (diff.HasChange("option_group_name") || diff.HasChange("engine_version")) && diff.NewValueKnown("option_group_name") {
+					if v := diff.Get("option_group_name").(string); v != "" && !strings.HasPrefix(v, "default:") {
+						var engineVersion string
+						if diff.NewValueKnown("engine_version") {
+							engineVersion = diff.Get("engine_version").(string)
+						}
+
+						if err := validateDbInstanceOptionGroup(conn, region, v, engineVersion); err != nil {
+							return err
+						}
+					}
+				}
+
+				return nil
+			},
+		),
 	}
 }
 
@@ -510,12 +928,25 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 		ApplyImmediately: aws.Bool(true),
 	}
 
+	if v, ok := d.GetOk("domain_iam_role_name"); ok {
+		if err := validateDbInstanceDomainIamRole(meta.(*AWSClient).iamconn, v.(string)); err != nil {
+			return err
+		}
+	}
+
+	if v := d.Get("security_group_names").(*schema.Set); v.Len() > 0 {
+		if err := validateDbInstanceSecurityGroupNamesPlatforms(meta.(*AWSClient).supportedplatforms); err != nil {
+			return err
+		}
+	}
+
 	// Some ModifyDBInstance parameters (e.g. DBParameterGroupName) require
 	// a database instance reboot to take affect. During resource creation,
 	// we expect everything to be in sync before returning completion.
 	var requiresRebootDbInstance bool
 
-	tags := keyvaluetags.New(d.Get("tags").(map[string]interface{})).IgnoreAws().RdsTags()
+	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
+	tags := defaultTagsConfig.MergeTags(keyvaluetags.New(d.Get("tags").(map[string]interface{}))).IgnoreAws().RdsTags()
 
 	var identifier string
 	if v, ok := d.GetOk("identifier"); ok {
@@ -530,7 +961,38 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 		d.Set("identifier", identifier)
 	}
 
+	if d.Get("create_monitoring_role").(bool) && d.Get("monitoring_interval").(int) > 0 {
+		roleARN, err := createDbInstanceMonitoringRole(meta.(*AWSClient).iamconn, meta.(*AWSClient).partition, identifier)
+		if err != nil {
+			return err
+		}
+
+		d.Set("monitoring_role_arn", roleARN)
+
+		// The DB instance is not saved to state if creating it fails, so
+		// remove the role rather than leaving it behind.
+		defer func() {
+			if d.Id() != "" {
+				return
+			}
+
+			if err := deleteDbInstanceMonitoringRole(meta.(*AWSClient).iamconn, meta.(*AWSClient).partition, roleARN); err != nil {
+				log.Printf("[WARN] %s", err)
+			}
+		}()
+	} else if v, ok := d.GetOk("monitoring_role_arn"); ok && d.Get("monitoring_interval").(int) > 0 {
+		if err := validateDbInstanceMonitoringRole(meta.(*AWSClient).iamconn, v.(string)); err != nil {
+			return err
+		}
+	}
+
 	if v, ok := d.GetOk("replicate_source_db"); ok {
+		// The source is only checked now, as its backup retention period can
+		// be changed in the same apply.
+		if err := validateDbInstanceReplicaSourceBackups(conn, meta.(*AWSClient).region, v.(string)); err != nil {
+			return err
+		}
+
 		opts := rds.CreateDBInstanceReadReplicaInput{
 			AutoMinorVersionUpgrade:    aws.Bool(d.Get("auto_minor_version_upgrade").(bool)),
 			CopyTagsToSnapshot:         aws.Bool(d.Get("copy_tags_to_snapshot").(bool)),
@@ -571,8 +1033,8 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.DBSubnetGroupName = aws.String(attr.(string))
 		}
 
-		if attr, ok := d.GetOk("enabled_cloudwatch_logs_exports"); ok && len(attr.([]interface{})) > 0 {
-			opts.EnableCloudwatchLogsExports = expandStringList(attr.([]interface{}))
+		if attr := d.Get("enabled_cloudwatch_logs_exports").(*schema.Set); attr.Len() > 0 {
+			opts.EnableCloudwatchLogsExports = expandStringSet(attr)
 		}
 
 		if attr, ok := d.GetOk("iam_database_authentication_enabled"); ok {
@@ -631,6 +1093,10 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.Port = aws.Int64(int64(attr.(int)))
 		}
 
+		if attr, ok := d.GetOk("replica_mode"); ok {
+			opts.ReplicaMode = aws.String(attr.(string))
+		}
+
 		if attr := d.Get("security_group_names").(*schema.Set); attr.Len() > 0 {
 			modifyDbInstanceInput.DBSecurityGroups = expandStringSet(attr)
 			requiresModifyDbInstance = true
@@ -656,37 +1122,66 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.PerformanceInsightsRetentionPeriod = aws.Int64(int64(attr.(int)))
 		}
 
-		if attr, ok := d.GetOk("ca_cert_identifier"); ok {
-			modifyDbInstanceInput.CACertificateIdentifier = aws.String(attr.(string))
-			requiresModifyDbInstance = true
+		caCertificateIdentifier := d.Get("ca_cert_identifier").(string)
+
+		// Without an explicit CA, replicas inherit the source's CA rather than
+		// the region default, so they match the source they replicate.
+		if caCertificateIdentifier == "" {
+			sourceCACertificateIdentifier, err := dbInstanceReplicaSourceCACertificateIdentifier(conn, meta.(*AWSClient).region, v.(string))
+			if err != nil {
+				return fmt.Errorf("error reading DB Instance replica source (%s): %s", v.(string), err)
+			}
+			caCertificateIdentifier = sourceCACertificateIdentifier
 		}
 
 		log.Printf("[DEBUG] DB Instance Replica create configuration: %#v", opts)
-		_, err := conn.CreateDBInstanceReadReplica(&opts)
+		var output *rds.CreateDBInstanceReadReplicaOutput
+		err := retryDbInstanceOptionGroupPropagation(aws.StringValue(opts.OptionGroupName), func() error {
+			var err error
+			output, err = conn.CreateDBInstanceReadReplica(&opts)
+			return err
+		})
 		if err != nil {
+			if isAWSErr(err, rds.ErrCodeDBSubnetGroupNotAllowedFault, "") {
+				return fmt.Errorf("Error creating DB Instance: db_subnet_group_name cannot be set for a read replica of an EC2-Classic DB instance: %s", err)
+			}
 			return fmt.Errorf("Error creating DB Instance: %s", err)
 		}
-	} else if v, ok := d.GetOk("s3_import"); ok {
 
-		if _, ok := d.GetOk("allocated_storage"); !ok {
-			return fmt.Errorf(`provider.aws: aws_db_instance: %s: "allocated_storage": required field is not set`, d.Get("name").(string))
-		}
-		if _, ok := d.GetOk("engine"); !ok {
-			return fmt.Errorf(`provider.aws: aws_db_instance: %s: "engine": required field is not set`, d.Get("name").(string))
+		if caCertificateIdentifier != "" && (output == nil || output.DBInstance == nil || aws.StringValue(output.DBInstance.CACertificateIdentifier) != caCertificateIdentifier) {
+			modifyDbInstanceInput.CACertificateIdentifier = aws.String(caCertificateIdentifier)
+			requiresModifyDbInstance = true
 		}
-		if _, ok := d.GetOk("password"); !ok {
+
+		if attr, ok := d.GetOk("engine_version"); ok && output != nil && output.DBInstance != nil && !dbInstanceEngineVersionHasMajorVersion(aws.StringValue(output.DBInstance.EngineVersion), attr.(string)) {
+			modifyDbInstanceInput.EngineVersion = aws.String(attr.(string))
+			requiresModifyDbInstance = true
+		}
+	} else if v, ok := d.GetOk("s3_import"); ok {
+		s3_bucket := v.([]interface{})[0].(map[string]interface{})
+
+		if err := validateDbInstanceS3ImportIngestionRole(meta.(*AWSClient).iamconn, s3_bucket["ingestion_role"].(string), s3_bucket["bucket_name"].(string), s3_bucket["bucket_prefix"].(string)); err != nil {
+			return err
+		}
+
+		if _, ok := d.GetOk("allocated_storage"); !ok {
+			return fmt.Errorf(`provider.aws: aws_db_instance: %s: "allocated_storage": required field is not set`, d.Get("name").(string))
+		}
+		if _, ok := d.GetOk("engine"); !ok {
+			return fmt.Errorf(`provider.aws: aws_db_instance: %s: "engine": required field is not set`, d.Get("name").(string))
+		}
+		if _, ok := d.GetOk("password"); !ok {
 			return fmt.Errorf(`provider.aws: aws_db_instance: %s: "password": required field is not set`, d.Get("name").(string))
 		}
 		if _, ok := d.GetOk("username"); !ok {
 			return fmt.Errorf(`provider.aws: aws_db_instance: %s: "username": required field is not set`, d.Get("name").(string))
 		}
 
-		s3_bucket := v.([]interface{})[0].(map[string]interface{})
 		opts := rds.RestoreDBInstanceFromS3Input{
 			AllocatedStorage:        aws.Int64(int64(d.Get("allocated_storage").(int))),
 			AutoMinorVersionUpgrade: aws.Bool(d.Get("auto_minor_version_upgrade").(bool)),
 			CopyTagsToSnapshot:      aws.Bool(d.Get("copy_tags_to_snapshot").(bool)),
-			DBName:                  aws.String(d.Get("name").(string)),
+			DBName:                  aws.String(dbInstanceDatabaseName(d)),
 			DBInstanceClass:         aws.String(d.Get("instance_class").(string)),
 			DBInstanceIdentifier:    aws.String(d.Get("identifier").(string)),
 			DeletionProtection:      aws.Bool(d.Get("deletion_protection").(bool)),
@@ -801,6 +1296,9 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 				if isAWSErr(err, "InvalidParameterValue", "ENHANCED_MONITORING") {
 					return resource.RetryableError(err)
 				}
+				if opts.OptionGroupName != nil && isDbInstanceOptionGroupPropagationError(err) {
+					return resource.RetryableError(err)
+				}
 				if isAWSErr(err, "InvalidParameterValue", "S3_SNAPSHOT_INGESTION") {
 					return resource.RetryableError(err)
 				}
@@ -831,7 +1329,7 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 
 		stateConf := &resource.StateChangeConf{
 			Pending:    resourceAwsDbInstanceCreatePendingStates,
-			Target:     []string{"available", "storage-optimization"},
+			Target:     resourceAwsDbInstanceAvailableStates,
 			Refresh:    resourceAwsDbInstanceStateRefreshFunc(d.Id(), conn),
 			Timeout:    d.Timeout(schema.TimeoutCreate),
 			MinTimeout: 10 * time.Second,
@@ -857,22 +1355,17 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			Tags:                    tags,
 		}
 
-		if attr, ok := d.GetOk("name"); ok {
+		if attr := dbInstanceDatabaseName(d); attr != "" {
 			// "Note: This parameter [DBName] doesn't apply to the MySQL, PostgreSQL, or MariaDB engines."
 			// https://docs.aws.amazon.com/AmazonRDS/latest/APIReference/API_RestoreDBInstanceFromDBSnapshot.html
 			switch strings.ToLower(d.Get("engine").(string)) {
 			case "mysql", "postgres", "mariadb":
 				// skip
 			default:
-				opts.DBName = aws.String(attr.(string))
+				opts.DBName = aws.String(attr)
 			}
 		}
 
-		if attr, ok := d.GetOk("allocated_storage"); ok {
-			modifyDbInstanceInput.AllocatedStorage = aws.Int64(int64(attr.(int)))
-			requiresModifyDbInstance = true
-		}
-
 		if attr, ok := d.GetOk("availability_zone"); ok {
 			opts.AvailabilityZone = aws.String(attr.(string))
 		}
@@ -905,19 +1398,14 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.DomainIAMRoleName = aws.String(attr.(string))
 		}
 
-		if attr, ok := d.GetOk("enabled_cloudwatch_logs_exports"); ok && len(attr.([]interface{})) > 0 {
-			opts.EnableCloudwatchLogsExports = expandStringList(attr.([]interface{}))
+		if attr := d.Get("enabled_cloudwatch_logs_exports").(*schema.Set); attr.Len() > 0 {
+			opts.EnableCloudwatchLogsExports = expandStringSet(attr)
 		}
 
 		if attr, ok := d.GetOk("engine"); ok {
 			opts.Engine = aws.String(attr.(string))
 		}
 
-		if attr, ok := d.GetOk("engine_version"); ok {
-			modifyDbInstanceInput.EngineVersion = aws.String(attr.(string))
-			requiresModifyDbInstance = true
-		}
-
 		if attr, ok := d.GetOk("iam_database_authentication_enabled"); ok {
 			opts.EnableIAMDatabaseAuthentication = aws.Bool(attr.(bool))
 		}
@@ -1001,6 +1489,8 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.VpcSecurityGroupIds = expandStringSet(attr)
 		}
 
+		// The restore APIs have no Performance Insights parameters, so it is
+		// enabled before the DB instance is first read.
 		if attr, ok := d.GetOk("performance_insights_enabled"); ok {
 			modifyDbInstanceInput.EnablePerformanceInsights = aws.Bool(attr.(bool))
 			requiresModifyDbInstance = true
@@ -1014,8 +1504,53 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			}
 		}
 
+		// RestoreDBInstanceFromDBSnapshot has no encryption parameters: the
+		// restored instance always uses the snapshot's encryption, so check that
+		// it matches any configured KMS key or explicitly disabled encryption.
+		kmsKeyID := d.Get("kms_key_id").(string)
+		storageEncrypted, ok := d.GetOkExists("storage_encrypted")
+		unencrypted := ok && !storageEncrypted.(bool)
+
+		if kmsKeyID != "" || unencrypted {
+			snapshotID := d.Get("snapshot_identifier").(string)
+			input := &rds.DescribeDBSnapshotsInput{
+				DBSnapshotIdentifier: aws.String(snapshotID),
+			}
+
+			if arn.IsARN(snapshotID) {
+				input.IncludeShared = aws.Bool(true)
+			}
+
+			output, err := conn.DescribeDBSnapshots(input)
+
+			if err != nil {
+				return fmt.Errorf("error reading DB Snapshot (%s): %w", snapshotID, err)
+			}
+
+			if output == nil || len(output.DBSnapshots) == 0 || output.DBSnapshots[0] == nil {
+				return fmt.Errorf("error reading DB Snapshot (%s): not found", snapshotID)
+			}
+
+			snapshot := output.DBSnapshots[0]
+
+			if unencrypted && aws.BoolValue(snapshot.Encrypted) {
+				return fmt.Errorf("storage_encrypted is false, but DB Snapshot (%s) is encrypted and RDS does not support restoring an encrypted DB snapshot into an unencrypted DB instance; remove storage_encrypted or set it to true", snapshotID)
+			}
+
+			if kmsKeyID != "" {
+				if err := validateDbInstanceSnapshotRestoreKmsKey(snapshot, kmsKeyID); err != nil {
+					return err
+				}
+			}
+		}
+
 		log.Printf("[DEBUG] DB Instance restore from snapshot configuration: %s", opts)
-		_, err := conn.RestoreDBInstanceFromDBSnapshot(&opts)
+		var restoreOutput *rds.RestoreDBInstanceFromDBSnapshotOutput
+		err := retryDbInstanceOptionGroupPropagation(aws.StringValue(opts.OptionGroupName), func() error {
+			var err error
+			restoreOutput, err = conn.RestoreDBInstanceFromDBSnapshot(&opts)
+			return err
+		})
 
 		// When using SQL Server engine with MultiAZ enabled, its not
 		// possible to immediately enable mirroring since
@@ -1029,12 +1564,158 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.MultiAZ = aws.Bool(false)
 			modifyDbInstanceInput.MultiAZ = aws.Bool(true)
 			requiresModifyDbInstance = true
-			_, err = conn.RestoreDBInstanceFromDBSnapshot(&opts)
+			restoreOutput, err = conn.RestoreDBInstanceFromDBSnapshot(&opts)
 		}
 
 		if err != nil {
 			return fmt.Errorf("Error creating DB Instance: %s", err)
 		}
+
+		// The restored instance inherits the snapshot's allocated storage, so
+		// only modify it when a different (larger) value is configured.
+		if attr, ok := d.GetOk("allocated_storage"); ok && restoreOutput.DBInstance != nil && int64(attr.(int)) != aws.Int64Value(restoreOutput.DBInstance.AllocatedStorage) {
+			modifyDbInstanceInput.AllocatedStorage = aws.Int64(int64(attr.(int)))
+			requiresModifyDbInstance = true
+		}
+
+		// Likewise the engine version, which may be configured partially,
+		// e.g. 5.6 for a snapshot of 5.6.41.
+		if attr, ok := d.GetOk("engine_version"); ok && restoreOutput.DBInstance != nil && !dbInstanceEngineVersionHasMajorVersion(aws.StringValue(restoreOutput.DBInstance.EngineVersion), attr.(string)) {
+			modifyDbInstanceInput.EngineVersion = aws.String(attr.(string))
+			requiresModifyDbInstance = true
+		}
+	} else if v, ok := d.GetOk("restore_to_point_in_time"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		opts := &rds.RestoreDBInstanceToPointInTimeInput{
+			AutoMinorVersionUpgrade:    aws.Bool(d.Get("auto_minor_version_upgrade").(bool)),
+			CopyTagsToSnapshot:         aws.Bool(d.Get("copy_tags_to_snapshot").(bool)),
+			DBInstanceClass:            aws.String(d.Get("instance_class").(string)),
+			DeletionProtection:         aws.Bool(d.Get("deletion_protection").(bool)),
+			PubliclyAccessible:         aws.Bool(d.Get("publicly_accessible").(bool)),
+			Tags:                       tags,
+			TargetDBInstanceIdentifier: aws.String(d.Get("identifier").(string)),
+		}
+
+		if err := expandDbInstanceRestoreToPointInTime(v.([]interface{})[0].(map[string]interface{}), opts); err != nil {
+			return err
+		}
+
+		if attr, ok := d.GetOk("availability_zone"); ok {
+			opts.AvailabilityZone = aws.String(attr.(string))
+		}
+
+		// The restored instance can be placed in a different network than
+		// the source DB instance.
+		if attr, ok := d.GetOk("db_subnet_group_name"); ok {
+			opts.DBSubnetGroupName = aws.String(attr.(string))
+		}
+
+		if attr := d.Get("vpc_security_group_ids").(*schema.Set); attr.Len() > 0 {
+			opts.VpcSecurityGroupIds = expandStringSet(attr)
+		}
+
+		if attr, ok := d.GetOk("domain"); ok {
+			opts.Domain = aws.String(attr.(string))
+		}
+
+		if attr, ok := d.GetOk("domain_iam_role_name"); ok {
+			opts.DomainIAMRoleName = aws.String(attr.(string))
+		}
+
+		if attr := d.Get("enabled_cloudwatch_logs_exports").(*schema.Set); attr.Len() > 0 {
+			opts.EnableCloudwatchLogsExports = expandStringSet(attr)
+		}
+
+		if attr, ok := d.GetOk("iam_database_authentication_enabled"); ok {
+			opts.EnableIAMDatabaseAuthentication = aws.Bool(attr.(bool))
+		}
+
+		if attr, ok := d.GetOk("iops"); ok {
+			opts.Iops = aws.Int64(int64(attr.(int)))
+		}
+
+		if attr, ok := d.GetOk("license_model"); ok {
+			opts.LicenseModel = aws.String(attr.(string))
+		}
+
+		if attr, ok := d.GetOk("multi_az"); ok {
+			opts.MultiAZ = aws.Bool(attr.(bool))
+		}
+
+		if attr, ok := d.GetOk("option_group_name"); ok {
+			opts.OptionGroupName = aws.String(attr.(string))
+		}
+
+		if attr, ok := d.GetOk("parameter_group_name"); ok {
+			opts.DBParameterGroupName = aws.String(attr.(string))
+		}
+
+		if attr, ok := d.GetOk("port"); ok {
+			opts.Port = aws.Int64(int64(attr.(int)))
+		}
+
+		if attr, ok := d.GetOk("storage_type"); ok {
+			opts.StorageType = aws.String(attr.(string))
+		}
+
+		// The following settings are not accepted by RestoreDBInstanceToPointInTime.
+		if attr, ok := d.GetOkExists("backup_retention_period"); ok {
+			modifyDbInstanceInput.BackupRetentionPeriod = aws.Int64(int64(attr.(int)))
+			requiresModifyDbInstance = true
+		}
+
+		if attr, ok := d.GetOk("backup_window"); ok {
+			modifyDbInstanceInput.PreferredBackupWindow = aws.String(attr.(string))
+			requiresModifyDbInstance = true
+		}
+
+		if attr, ok := d.GetOk("maintenance_window"); ok {
+			modifyDbInstanceInput.PreferredMaintenanceWindow = aws.String(attr.(string))
+			requiresModifyDbInstance = true
+		}
+
+		if attr, ok := d.GetOk("monitoring_interval"); ok && attr.(int) > 0 {
+			modifyDbInstanceInput.MonitoringInterval = aws.Int64(int64(attr.(int)))
+			requiresModifyDbInstance = true
+		}
+
+		if attr, ok := d.GetOk("monitoring_role_arn"); ok {
+			modifyDbInstanceInput.MonitoringRoleArn = aws.String(attr.(string))
+			requiresModifyDbInstance = true
+		}
+
+		// The restore APIs have no Performance Insights parameters, so it is
+		// enabled before the DB instance is first read.
+		if attr, ok := d.GetOk("performance_insights_enabled"); ok {
+			modifyDbInstanceInput.EnablePerformanceInsights = aws.Bool(attr.(bool))
+			requiresModifyDbInstance = true
+
+			if attr, ok := d.GetOk("performance_insights_kms_key_id"); ok {
+				modifyDbInstanceInput.PerformanceInsightsKMSKeyId = aws.String(attr.(string))
+			}
+
+			if attr, ok := d.GetOk("performance_insights_retention_period"); ok {
+				modifyDbInstanceInput.PerformanceInsightsRetentionPeriod = aws.Int64(int64(attr.(int)))
+			}
+		}
+
+		log.Printf("[DEBUG] DB Instance restore to point in time configuration: %s", opts)
+		var restoreOutput *rds.RestoreDBInstanceToPointInTimeOutput
+		err := retryDbInstanceOptionGroupPropagation(aws.StringValue(opts.OptionGroupName), func() error {
+			var err error
+			restoreOutput, err = conn.RestoreDBInstanceToPointInTime(opts)
+			return err
+		})
+
+		if err != nil {
+			return fmt.Errorf("error creating DB Instance (restore to point-in-time): %w", err)
+		}
+
+		// The restored instance has the source's engine version, which may be
+		// configured partially, e.g. 5.6 for a source of 5.6.41.
+		if attr, ok := d.GetOk("engine_version"); ok && restoreOutput != nil && restoreOutput.DBInstance != nil && !dbInstanceEngineVersionHasMajorVersion(aws.StringValue(restoreOutput.DBInstance.EngineVersion), attr.(string)) {
+			modifyDbInstanceInput.EngineVersion = aws.String(attr.(string))
+			requiresModifyDbInstance = true
+		}
 	} else {
 		if _, ok := d.GetOk("allocated_storage"); !ok {
 			return fmt.Errorf(`provider.aws: aws_db_instance: %s: "allocated_storage": required field is not set`, d.Get("name").(string))
@@ -1051,7 +1732,7 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 
 		opts := rds.CreateDBInstanceInput{
 			AllocatedStorage:        aws.Int64(int64(d.Get("allocated_storage").(int))),
-			DBName:                  aws.String(d.Get("name").(string)),
+			DBName:                  aws.String(dbInstanceDatabaseName(d)),
 			DBInstanceClass:         aws.String(d.Get("instance_class").(string)),
 			DBInstanceIdentifier:    aws.String(d.Get("identifier").(string)),
 			DeletionProtection:      aws.Bool(d.Get("deletion_protection").(bool)),
@@ -1093,7 +1774,9 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.LicenseModel = aws.String(attr.(string))
 		}
 
-		if attr, ok := d.GetOk("max_allocated_storage"); ok {
+		// Storage autoscaling is disabled by a maximum equal to the allocated
+		// storage, which CreateDBInstance does not accept.
+		if attr, ok := d.GetOk("max_allocated_storage"); ok && attr.(int) != d.Get("allocated_storage").(int) {
 			opts.MaxAllocatedStorage = aws.Int64(int64(attr.(int)))
 		}
 
@@ -1116,8 +1799,8 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 			opts.DBSubnetGroupName = aws.String(attr.(string))
 		}
 
-		if attr, ok := d.GetOk("enabled_cloudwatch_logs_exports"); ok && len(attr.([]interface{})) > 0 {
-			opts.EnableCloudwatchLogsExports = expandStringList(attr.([]interface{}))
+		if attr := d.Get("enabled_cloudwatch_logs_exports").(*schema.Set); attr.Len() > 0 {
+			opts.EnableCloudwatchLogsExports = expandStringSet(attr)
 		}
 
 		if attr, ok := d.GetOk("iops"); ok {
@@ -1181,12 +1864,15 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 				if isAWSErr(err, "InvalidParameterValue", "ENHANCED_MONITORING") {
 					return resource.RetryableError(err)
 				}
+				if opts.OptionGroupName != nil && isDbInstanceOptionGroupPropagationError(err) {
+					return resource.RetryableError(err)
+				}
 				return resource.NonRetryableError(err)
 			}
 			return nil
 		})
 		if isResourceTimeoutError(err) {
-			_, err = conn.CreateDBInstance(&opts)
+			createdDBInstanceOutput, err = conn.CreateDBInstance(&opts)
 		}
 		if err != nil {
 			if isAWSErr(err, "InvalidParameterValue", "") {
@@ -1206,7 +1892,7 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    resourceAwsDbInstanceCreatePendingStates,
-		Target:     []string{"available", "storage-optimization"},
+		Target:     resourceAwsDbInstanceAvailableStates,
 		Refresh:    resourceAwsDbInstanceStateRefreshFunc(d.Id(), conn),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
 		MinTimeout: 10 * time.Second,
@@ -1258,6 +1944,7 @@ func resourceAwsDbInstanceCreate(d *schema.ResourceData, meta interface{}) error
 
 func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).rdsconn
+	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
 	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
 
 	v, err := resourceAwsDbInstanceRetrieve(d.Id(), conn)
@@ -1270,6 +1957,7 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
+	d.Set("db_name", v.DBName)
 	d.Set("name", v.DBName)
 	d.Set("identifier", v.DBInstanceIdentifier)
 	d.Set("resource_id", v.DbiResourceId)
@@ -1292,7 +1980,6 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("publicly_accessible", v.PubliclyAccessible)
 	d.Set("multi_az", v.MultiAZ)
 	d.Set("kms_key_id", v.KmsKeyId)
-	d.Set("port", v.DbInstancePort)
 	d.Set("iam_database_authentication_enabled", v.IAMDatabaseAuthenticationEnabled)
 	d.Set("performance_insights_enabled", v.PerformanceInsightsEnabled)
 	d.Set("performance_insights_kms_key_id", v.PerformanceInsightsKMSKeyId)
@@ -1311,8 +1998,16 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("parameter_group_name", v.DBParameterGroups[0].DBParameterGroupName)
 	}
 
-	if v.Endpoint != nil {
+	// The endpoint port is what clients actually connect to, while
+	// DbInstancePort can be reported before a port change has taken effect.
+	if v.Endpoint != nil && v.Endpoint.Port != nil {
 		d.Set("port", v.Endpoint.Port)
+	} else {
+		d.Set("port", v.DbInstancePort)
+	}
+	d.Set("db_instance_port", v.DbInstancePort)
+
+	if v.Endpoint != nil {
 		d.Set("address", v.Endpoint.Address)
 		d.Set("hosted_zone_id", v.Endpoint.HostedZoneId)
 		if v.Endpoint.Address != nil && v.Endpoint.Port != nil {
@@ -1323,17 +2018,29 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("status", v.DBInstanceStatus)
 	d.Set("storage_encrypted", v.StorageEncrypted)
-	if v.OptionGroupMemberships != nil {
-		d.Set("option_group_name", v.OptionGroupMemberships[0].OptionGroupName)
+	// When option_group_name is omitted, RDS assigns the engine's default
+	// option group (e.g. default:mysql-5-6), which is stored so it does not drift.
+	if membership := dbInstanceCurrentOptionGroupMembership(v.OptionGroupMemberships); membership != nil {
+		d.Set("option_group_name", membership.OptionGroupName)
+		d.Set("option_group_status", membership.Status)
 	}
 
 	d.Set("monitoring_interval", v.MonitoringInterval)
-	d.Set("monitoring_role_arn", v.MonitoringRoleArn)
 
-	if err := d.Set("enabled_cloudwatch_logs_exports", flattenStringList(v.EnabledCloudwatchLogsExports)); err != nil {
+	// The role is not returned while enhanced monitoring is disabled, so keep
+	// the configured role to avoid drift and allow re-enabling it later.
+	if v.MonitoringRoleArn != nil || aws.Int64Value(v.MonitoringInterval) > 0 {
+		d.Set("monitoring_role_arn", v.MonitoringRoleArn)
+	}
+
+	if err := d.Set("enabled_cloudwatch_logs_exports", flattenStringSet(v.EnabledCloudwatchLogsExports)); err != nil {
 		return fmt.Errorf("error setting enabled_cloudwatch_logs_exports: %s", err)
 	}
 
+	if err := d.Set("pending_modified_values", flattenDbInstancePendingModifiedValues(v.PendingModifiedValues)); err != nil {
+		return fmt.Errorf("error setting pending_modified_values: %s", err)
+	}
+
 	d.Set("domain", "")
 	d.Set("domain_iam_role_name", "")
 	if len(v.DomainMemberships) > 0 && v.DomainMemberships[0] != nil {
@@ -1350,10 +2057,16 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error listing tags for RDS DB Instance (%s): %s", d.Get("arn").(string), err)
 	}
 
-	if err := d.Set("tags", tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+	tags = tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+
+	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
 		return fmt.Errorf("error setting tags: %s", err)
 	}
 
+	if err := d.Set("tags_all", tags.Map()); err != nil {
+		return fmt.Errorf("error setting tags_all: %s", err)
+	}
+
 	// Create an empty schema.Set to hold all vpc security group ids
 	ids := &schema.Set{
 		F: schema.HashString,
@@ -1381,13 +2094,73 @@ func resourceAwsDbInstanceRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error setting replicas attribute: %#v, error: %#v", replicas, err)
 	}
 
-	d.Set("replicate_source_db", v.ReadReplicaSourceDBInstanceIdentifier)
+	// A replica whose source was deleted is promoted to a standalone instance
+	// by RDS; keep the configured source rather than electing a new one.
+	if source := d.Get("replicate_source_db").(string); source != "" && v.ReadReplicaSourceDBInstanceIdentifier == nil {
+		log.Printf("[WARN] DB Instance (%s) is no longer a read replica of (%s), its source may have been deleted; treating it as promoted", d.Id(), source)
+	} else {
+		d.Set("replicate_source_db", v.ReadReplicaSourceDBInstanceIdentifier)
+	}
+
+	d.Set("replica_mode", v.ReplicaMode)
 
 	d.Set("ca_cert_identifier", v.CACertificateIdentifier)
 
+	var certificate *rds.Certificate
+	if caCertIdentifier := aws.StringValue(v.CACertificateIdentifier); caCertIdentifier != "" {
+		var err error
+		certificate, err = dbInstanceCertificate(conn, meta.(*AWSClient).rdsCertificatesCache, caCertIdentifier)
+
+		// The certificate details are informational, so do not fail the read
+		// e.g. when rds:DescribeCertificates is not permitted.
+		if err != nil {
+			log.Printf("[WARN] Unable to read DB Instance (%s) certificate (%s): %s", d.Id(), caCertIdentifier, err)
+		}
+	}
+
+	if err := d.Set("certificate_details", flattenDbInstanceCertificateDetails(certificate)); err != nil {
+		return fmt.Errorf("error setting certificate_details: %w", err)
+	}
+
 	return nil
 }
 
+// dbInstanceCertificate returns the CA certificate with the given identifier
+// from the shared certificates cache, or nil if it does not exist.
+func dbInstanceCertificate(conn *rds.RDS, cache *rdsCertificatesCache, identifier string) (*rds.Certificate, error) {
+	certificates, err := cache.get(func() ([]*rds.Certificate, error) {
+		return describeRdsCertificates(conn)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, certificate := range certificates {
+		if certificate != nil && aws.StringValue(certificate.CertificateIdentifier) == identifier {
+			return certificate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func flattenDbInstanceCertificateDetails(certificate *rds.Certificate) []interface{} {
+	if certificate == nil {
+		return nil
+	}
+
+	tfMap := map[string]interface{}{
+		"ca_identifier": aws.StringValue(certificate.CertificateIdentifier),
+	}
+
+	if certificate.ValidTill != nil {
+		tfMap["valid_till"] = aws.TimeValue(certificate.ValidTill).Format(time.RFC3339)
+	}
+
+	return []interface{}{tfMap}
+}
+
 func resourceAwsDbInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).rdsconn
 
@@ -1409,6 +2182,14 @@ func resourceAwsDbInstanceDelete(d *schema.ResourceData, meta interface{}) error
 	deleteAutomatedBackups := d.Get("delete_automated_backups").(bool)
 	opts.DeleteAutomatedBackups = aws.Bool(deleteAutomatedBackups)
 
+	// Deletion protection must be disabled before the instance, and with it
+	// any final snapshot, can be deleted.
+	if d.Get("force_destroy").(bool) && d.Get("deletion_protection").(bool) {
+		if err := disableDbInstanceDeletionProtection(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[DEBUG] DB Instance destroy configuration: %v", opts)
 	_, err := conn.DeleteDBInstance(&opts)
 
@@ -1418,13 +2199,46 @@ func resourceAwsDbInstanceDelete(d *schema.ResourceData, meta interface{}) error
 	}
 
 	log.Println("[INFO] Waiting for DB Instance to be destroyed")
-	return waitUntilAwsDbInstanceIsDeleted(d.Id(), conn, d.Timeout(schema.TimeoutDelete))
+	if err := waitUntilAwsDbInstanceIsDeleted(d.Id(), conn, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
+
+	if v, ok := d.GetOk("monitoring_role_arn"); ok && d.Get("create_monitoring_role").(bool) {
+		return deleteDbInstanceMonitoringRole(meta.(*AWSClient).iamconn, meta.(*AWSClient).partition, v.(string))
+	}
+
+	return nil
+}
+
+func disableDbInstanceDeletionProtection(conn *rds.RDS, id string, timeout time.Duration) error {
+	input := &rds.ModifyDBInstanceInput{
+		ApplyImmediately:     aws.Bool(true),
+		DBInstanceIdentifier: aws.String(id),
+		DeletionProtection:   aws.Bool(false),
+	}
+
+	log.Printf("[DEBUG] Disabling DB Instance (%s) deletion protection: %s", id, input)
+	_, err := conn.ModifyDBInstance(input)
+
+	if isAWSErr(err, rds.ErrCodeDBInstanceNotFoundFault, "") {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error disabling DB Instance (%s) deletion protection: %s", id, err)
+	}
+
+	if err := waitUntilAwsDbInstanceIsAvailableAfterUpdate(id, conn, timeout); err != nil {
+		return fmt.Errorf("error waiting for DB Instance (%s) deletion protection to be disabled: %s", id, err)
+	}
+
+	return nil
 }
 
 func waitUntilAwsDbInstanceIsAvailableAfterUpdate(id string, conn *rds.RDS, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
 		Pending:    resourceAwsDbInstanceUpdatePendingStates,
-		Target:     []string{"available", "storage-optimization"},
+		Target:     resourceAwsDbInstanceAvailableStates,
 		Refresh:    resourceAwsDbInstanceStateRefreshFunc(id, conn),
 		Timeout:    timeout,
 		MinTimeout: 10 * time.Second,
@@ -1434,6 +2248,21 @@ func waitUntilAwsDbInstanceIsAvailableAfterUpdate(id string, conn *rds.RDS, time
 	return err
 }
 
+// waitUntilAwsDbInstanceIsAvailableBeforeUpdate is like
+// waitUntilAwsDbInstanceIsAvailableAfterUpdate, but without the initial delay
+// as the instance is usually already available.
+func waitUntilAwsDbInstanceIsAvailableBeforeUpdate(id string, conn *rds.RDS, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    resourceAwsDbInstanceUpdatePendingStates,
+		Target:     resourceAwsDbInstanceAvailableStates,
+		Refresh:    resourceAwsDbInstanceStateRefreshFunc(id, conn),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
 func waitUntilAwsDbInstanceIsDeleted(id string, conn *rds.RDS, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
 		Pending:    resourceAwsDbInstanceDeletePendingStates,
@@ -1447,21 +2276,155 @@ func waitUntilAwsDbInstanceIsDeleted(id string, conn *rds.RDS, timeout time.Dura
 	return err
 }
 
-func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AWSClient).rdsconn
-
-	req := &rds.ModifyDBInstanceInput{
-		ApplyImmediately:     aws.Bool(d.Get("apply_immediately").(bool)),
-		DBInstanceIdentifier: aws.String(d.Id()),
-	}
+// waitUntilAwsDbInstanceIsUpdated waits until updated reports that the DB
+// instance reflects a modification which RDS applies asynchronously, e.g.
+// after the DB instance already reports available again.
+func waitUntilAwsDbInstanceIsUpdated(id string, conn *rds.RDS, timeout time.Duration, updated func(*rds.DBInstance) bool) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"updated"},
+		Refresh: func() (interface{}, string, error) {
+			v, err := resourceAwsDbInstanceRetrieve(id, conn)
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			if v == nil {
+				return nil, "", fmt.Errorf("DB Instance (%s) not found", id)
+			}
+
+			if !updated(v) {
+				return v, "pending", nil
+			}
+
+			return v, "updated", nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// dbInstanceOptionGroupMembershipStatus returns the status of the DB instance's
+// membership of the given option group, or "" if it is not a member.
+func dbInstanceOptionGroupMembershipStatus(v *rds.DBInstance, optionGroupName string) string {
+	for _, membership := range v.OptionGroupMemberships {
+		if membership == nil || aws.StringValue(membership.OptionGroupName) != optionGroupName {
+			continue
+		}
+
+		return aws.StringValue(membership.Status)
+	}
+
+	return ""
+}
+
+func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	// Rename the DB instance first, so that all other changes are made with
+	// the new identifier. Renaming is always applied immediately, as the
+	// resource ID would otherwise not match the DB instance.
+	if d.HasChange("identifier") {
+		newIdentifier := d.Get("identifier").(string)
+		input := &rds.ModifyDBInstanceInput{
+			ApplyImmediately:        aws.Bool(true),
+			DBInstanceIdentifier:    aws.String(d.Id()),
+			NewDBInstanceIdentifier: aws.String(newIdentifier),
+		}
+
+		log.Printf("[DEBUG] Renaming DB Instance (%s): %s", d.Id(), input)
+		if _, err := conn.ModifyDBInstance(input); err != nil {
+			return fmt.Errorf("error renaming DB Instance (%s) to (%s): %s", d.Id(), newIdentifier, err)
+		}
+
+		d.SetId(newIdentifier)
+
+		log.Printf("[DEBUG] Waiting for DB Instance (%s) to be available", d.Id())
+		if err := waitUntilAwsDbInstanceIsAvailableAfterUpdate(d.Id(), conn, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for DB Instance (%s) to be available: %s", d.Id(), err)
+		}
+
+		// The ARN contains the identifier and is used for tagging below.
+		v, err := resourceAwsDbInstanceRetrieve(d.Id(), conn)
+		if err != nil {
+			return fmt.Errorf("error reading DB Instance (%s): %s", d.Id(), err)
+		}
+		if v != nil {
+			d.Set("arn", v.DBInstanceArn)
+		}
+	}
+
+	// Password changes are always applied immediately, so that a new
+	// password is never queued for the maintenance window. They are sent
+	// separately so that other changes still honor apply_immediately.
+	if d.HasChange("password") {
+		input := &rds.ModifyDBInstanceInput{
+			ApplyImmediately:     aws.Bool(true),
+			DBInstanceIdentifier: aws.String(d.Id()),
+			MasterUserPassword:   aws.String(d.Get("password").(string)),
+		}
+
+		log.Printf("[DEBUG] Changing DB Instance (%s) master user password", d.Id())
+		if _, err := conn.ModifyDBInstance(input); err != nil {
+			return fmt.Errorf("error changing DB Instance (%s) master user password: %s", d.Id(), err)
+		}
+
+		log.Printf("[DEBUG] Waiting for DB Instance (%s) to be available", d.Id())
+		if err := waitUntilAwsDbInstanceIsAvailableAfterUpdate(d.Id(), conn, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for DB Instance (%s) to be available: %s", d.Id(), err)
+		}
+	}
+
+	req := &rds.ModifyDBInstanceInput{
+		ApplyImmediately:     aws.Bool(d.Get("apply_immediately").(bool)),
+		DBInstanceIdentifier: aws.String(d.Id()),
+	}
 
 	if !aws.BoolValue(req.ApplyImmediately) {
 		log.Println("[INFO] Only settings updating, instance changes will be applied in next maintenance window")
 	}
 
+	// Enhanced monitoring roles created by create_monitoring_role are created
+	// before and deleted after modifying the DB instance.
+	var monitoringRoleCreated bool
+	var deleteMonitoringRoleARN string
+
+	if d.HasChanges("create_monitoring_role", "monitoring_interval") {
+		o, n := d.GetChange("create_monitoring_role")
+		monitoringInterval := d.Get("monitoring_interval").(int)
+
+		if o.(bool) {
+			oldRoleARN, _ := d.GetChange("monitoring_role_arn")
+			deleteMonitoringRoleARN = oldRoleARN.(string)
+		}
+
+		switch {
+		case n.(bool) && monitoringInterval > 0 && (!o.(bool) || d.Get("monitoring_role_arn").(string) == ""):
+			roleARN, err := createDbInstanceMonitoringRole(meta.(*AWSClient).iamconn, meta.(*AWSClient).partition, d.Id())
+			if err != nil {
+				return err
+			}
+
+			d.Set("monitoring_role_arn", roleARN)
+			monitoringRoleCreated = true
+		case n.(bool):
+			// Keep the existing role.
+			deleteMonitoringRoleARN = ""
+		case o.(bool) && monitoringInterval > 0 && !d.HasChange("monitoring_role_arn"):
+			return fmt.Errorf("monitoring_role_arn is required when disabling create_monitoring_role while monitoring_interval is greater than 0")
+		}
+	}
+
 	requestUpdate := false
 	if d.HasChanges("allocated_storage", "iops") {
-		req.Iops = aws.Int64(int64(d.Get("iops").(int)))
+		// Provisioned IOPS, including 0, are rejected for other storage
+		// types, e.g. when switching from io1 to gp2.
+		if d.Get("storage_type").(string) == "io1" {
+			req.Iops = aws.Int64(int64(d.Get("iops").(int)))
+		}
 		req.AllocatedStorage = aws.Int64(int64(d.Get("allocated_storage").(int)))
 		requestUpdate = true
 	}
@@ -1520,10 +2483,6 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		req.MaxAllocatedStorage = aws.Int64(int64(mas))
 		requestUpdate = true
 	}
-	if d.HasChange("password") {
-		req.MasterUserPassword = aws.String(d.Get("password").(string))
-		requestUpdate = true
-	}
 	if d.HasChange("multi_az") {
 		req.MultiAZ = aws.Bool(d.Get("multi_az").(bool))
 		requestUpdate = true
@@ -1532,6 +2491,10 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		req.PubliclyAccessible = aws.Bool(d.Get("publicly_accessible").(bool))
 		requestUpdate = true
 	}
+	if d.HasChange("replica_mode") {
+		req.ReplicaMode = aws.String(d.Get("replica_mode").(string))
+		requestUpdate = true
+	}
 	if d.HasChange("storage_type") {
 		req.StorageType = aws.String(d.Get("storage_type").(string))
 		requestUpdate = true
@@ -1545,13 +2508,23 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		requestUpdate = true
 	}
 
-	if d.HasChange("monitoring_role_arn") {
-		req.MonitoringRoleArn = aws.String(d.Get("monitoring_role_arn").(string))
-		requestUpdate = true
-	}
+	if d.HasChanges("monitoring_interval", "monitoring_role_arn") || monitoringRoleCreated {
+		monitoringInterval := d.Get("monitoring_interval").(int)
+		req.MonitoringInterval = aws.Int64(int64(monitoringInterval))
+
+		// An interval of 0 disables enhanced monitoring and cannot be combined
+		// with a role, while re-enabling it always requires the role.
+		// InvalidParameterCombination: You must specify a MonitoringInterval value other than 0 when you specify a MonitoringRoleARN value.
+		if v, ok := d.GetOk("monitoring_role_arn"); ok && monitoringInterval > 0 {
+			if d.HasChange("monitoring_role_arn") && !monitoringRoleCreated {
+				if err := validateDbInstanceMonitoringRole(meta.(*AWSClient).iamconn, v.(string)); err != nil {
+					return err
+				}
+			}
+
+			req.MonitoringRoleArn = aws.String(v.(string))
+		}
 
-	if d.HasChange("monitoring_interval") {
-		req.MonitoringInterval = aws.Int64(int64(d.Get("monitoring_interval").(int)))
 		requestUpdate = true
 	}
 
@@ -1570,7 +2543,20 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if d.HasChange("option_group_name") {
-		req.OptionGroupName = aws.String(d.Get("option_group_name").(string))
+		optionGroupName := d.Get("option_group_name").(string)
+
+		// Removing option_group_name reverts to the engine's default option
+		// group, which has to be named explicitly in the modify request.
+		if optionGroupName == "" {
+			var err error
+			optionGroupName, err = dbInstanceDefaultOptionGroupName(conn, d.Get("engine").(string), d.Get("engine_version").(string))
+
+			if err != nil {
+				return fmt.Errorf("error reverting DB Instance (%s) to the default option group: %w", d.Id(), err)
+			}
+		}
+
+		req.OptionGroupName = aws.String(optionGroupName)
 		requestUpdate = true
 	}
 
@@ -1584,7 +2570,13 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if d.HasChange("enabled_cloudwatch_logs_exports") {
-		req.CloudwatchLogsExportConfiguration = buildCloudwatchLogsExportConfiguration(d)
+		o, n := d.GetChange("enabled_cloudwatch_logs_exports")
+		enable, disable := diffCloudwatchLogsExportConfiguration(o.(*schema.Set).List(), n.(*schema.Set).List())
+
+		req.CloudwatchLogsExportConfiguration = &rds.CloudwatchLogsExportConfiguration{
+			EnableLogTypes:  expandStringList(enable),
+			DisableLogTypes: expandStringList(disable),
+		}
 		requestUpdate = true
 	}
 
@@ -1594,20 +2586,37 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if d.HasChanges("domain", "domain_iam_role_name") {
-		req.Domain = aws.String(d.Get("domain").(string))
-		req.DomainIAMRoleName = aws.String(d.Get("domain_iam_role_name").(string))
-		requestUpdate = true
+		if v, ok := d.GetOk("domain_iam_role_name"); ok && d.HasChange("domain_iam_role_name") {
+			if err := validateDbInstanceDomainIamRole(meta.(*AWSClient).iamconn, v.(string)); err != nil {
+				return err
+			}
+		}
+
+		if domain := d.Get("domain").(string); domain != "" {
+			req.Domain = aws.String(domain)
+			req.DomainIAMRoleName = aws.String(d.Get("domain_iam_role_name").(string))
+			requestUpdate = true
+		} else if o, _ := d.GetChange("domain"); o.(string) != "" {
+			// An empty domain is ignored, none removes the DB instance from
+			// its current domain.
+			req.Domain = aws.String("none")
+			requestUpdate = true
+		}
 	}
 
 	if d.HasChanges("performance_insights_enabled", "performance_insights_kms_key_id", "performance_insights_retention_period") {
 		req.EnablePerformanceInsights = aws.Bool(d.Get("performance_insights_enabled").(bool))
 
-		if v, ok := d.GetOk("performance_insights_kms_key_id"); ok {
-			req.PerformanceInsightsKMSKeyId = aws.String(v.(string))
-		}
+		// The key and retention period only apply while Performance Insights
+		// is enabled.
+		if aws.BoolValue(req.EnablePerformanceInsights) {
+			if v, ok := d.GetOk("performance_insights_kms_key_id"); ok {
+				req.PerformanceInsightsKMSKeyId = aws.String(v.(string))
+			}
 
-		if v, ok := d.GetOk("performance_insights_retention_period"); ok {
-			req.PerformanceInsightsRetentionPeriod = aws.Int64(int64(v.(int)))
+			if v, ok := d.GetOk("performance_insights_retention_period"); ok {
+				req.PerformanceInsightsRetentionPeriod = aws.Int64(int64(v.(int)))
+			}
 		}
 
 		requestUpdate = true
@@ -1617,7 +2626,15 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 	if requestUpdate {
 		log.Printf("[DEBUG] DB Instance Modification request: %s", req)
 
-		err := resource.Retry(2*time.Minute, func() *resource.RetryError {
+		// The instance cannot be modified while it is rebooting, e.g. to
+		// apply a parameter group change from a previous apply.
+		log.Printf("[DEBUG] Waiting for DB Instance (%s) to be available before modification", d.Id())
+		err := waitUntilAwsDbInstanceIsAvailableBeforeUpdate(d.Id(), conn, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return fmt.Errorf("error waiting for DB Instance (%s) to be available before modification: %s", d.Id(), err)
+		}
+
+		err = resource.Retry(2*time.Minute, func() *resource.RetryError {
 			_, err := conn.ModifyDBInstance(req)
 
 			// Retry for IAM eventual consistency
@@ -1645,6 +2662,137 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		if err != nil {
 			return fmt.Errorf("error waiting for DB Instance (%s) to be available: %s", d.Id(), err)
 		}
+
+		// The instance can report available before the endpoint reflects
+		// the new port, which would otherwise be read back as the old value.
+		if req.DBPortNumber != nil && aws.BoolValue(req.ApplyImmediately) {
+			log.Printf("[DEBUG] Waiting for DB Instance (%s) endpoint port to be updated", d.Id())
+			port := aws.Int64Value(req.DBPortNumber)
+			err = waitUntilAwsDbInstanceIsUpdated(d.Id(), conn, d.Timeout(schema.TimeoutUpdate), func(v *rds.DBInstance) bool {
+				return v.Endpoint != nil && aws.Int64Value(v.Endpoint.Port) == port
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) endpoint port to be updated: %s", d.Id(), err)
+			}
+		}
+
+		// The new CA is applied while the instance reboots, which can start
+		// after the instance reports available. Waiting here also ensures a
+		// replica rotated in the same apply is not modified mid-reboot of its
+		// source.
+		if req.CACertificateIdentifier != nil && aws.BoolValue(req.ApplyImmediately) {
+			log.Printf("[DEBUG] Waiting for DB Instance (%s) CA certificate identifier to be updated", d.Id())
+			caCertIdentifier := aws.StringValue(req.CACertificateIdentifier)
+			err = waitUntilAwsDbInstanceIsUpdated(d.Id(), conn, d.Timeout(schema.TimeoutUpdate), func(v *rds.DBInstance) bool {
+				return aws.StringValue(v.CACertificateIdentifier) == caCertIdentifier
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) CA certificate identifier to be updated: %s", d.Id(), err)
+			}
+
+			err = waitUntilAwsDbInstanceIsAvailableBeforeUpdate(d.Id(), conn, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) to be available: %s", d.Id(), err)
+			}
+		}
+
+		// Log exports are always changed immediately, but can still be pending
+		// when the instance reports available, e.g. when all are disabled.
+		if req.CloudwatchLogsExportConfiguration != nil {
+			log.Printf("[DEBUG] Waiting for DB Instance (%s) CloudWatch logs exports to be updated", d.Id())
+			err = waitUntilAwsDbInstanceIsUpdated(d.Id(), conn, d.Timeout(schema.TimeoutUpdate), func(v *rds.DBInstance) bool {
+				if v.PendingModifiedValues == nil || v.PendingModifiedValues.PendingCloudwatchLogsExports == nil {
+					return true
+				}
+				pending := v.PendingModifiedValues.PendingCloudwatchLogsExports
+				return len(pending.LogTypesToDisable) == 0 && len(pending.LogTypesToEnable) == 0
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) CloudWatch logs exports to be updated: %s", d.Id(), err)
+			}
+		}
+
+		// The domain membership is only removed once the instance has left
+		// the domain, which happens after it reports available.
+		if aws.StringValue(req.Domain) == "none" && aws.BoolValue(req.ApplyImmediately) {
+			log.Printf("[DEBUG] Waiting for DB Instance (%s) to be removed from its domain", d.Id())
+			err = waitUntilAwsDbInstanceIsUpdated(d.Id(), conn, d.Timeout(schema.TimeoutUpdate), func(v *rds.DBInstance) bool {
+				for _, membership := range v.DomainMemberships {
+					if membership != nil {
+						return false
+					}
+				}
+				return true
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) to be removed from its domain: %s", d.Id(), err)
+			}
+		}
+
+		// Enabling or disabling automated backups, i.e. changing the backup
+		// retention period from or to 0, can also report available before the
+		// new retention period is reflected and the first backup has started.
+		if req.BackupRetentionPeriod != nil && aws.BoolValue(req.ApplyImmediately) {
+			log.Printf("[DEBUG] Waiting for DB Instance (%s) backup retention period to be updated", d.Id())
+			backupRetentionPeriod := aws.Int64Value(req.BackupRetentionPeriod)
+			err = waitUntilAwsDbInstanceIsUpdated(d.Id(), conn, d.Timeout(schema.TimeoutUpdate), func(v *rds.DBInstance) bool {
+				return aws.Int64Value(v.BackupRetentionPeriod) == backupRetentionPeriod
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) backup retention period to be updated: %s", d.Id(), err)
+			}
+
+			err = waitUntilAwsDbInstanceIsAvailableBeforeUpdate(d.Id(), conn, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) to be available: %s", d.Id(), err)
+			}
+		}
+
+		// Parameter and option group changes are sent in the same request
+		// above, but both are applied asynchronously once the instance is
+		// available again. Static parameters stay pending-reboot.
+		if req.DBParameterGroupName != nil && aws.BoolValue(req.ApplyImmediately) {
+			log.Printf("[DEBUG] Waiting for DB Instance (%s) parameter group (%s) to be applied", d.Id(), aws.StringValue(req.DBParameterGroupName))
+			parameterGroupName := aws.StringValue(req.DBParameterGroupName)
+			err = waitUntilAwsDbInstanceIsUpdated(d.Id(), conn, d.Timeout(schema.TimeoutUpdate), func(v *rds.DBInstance) bool {
+				for _, dbParameterGroup := range v.DBParameterGroups {
+					if dbParameterGroup == nil || aws.StringValue(dbParameterGroup.DBParameterGroupName) != parameterGroupName {
+						continue
+					}
+					switch aws.StringValue(dbParameterGroup.ParameterApplyStatus) {
+					case "in-sync", "pending-reboot":
+						return true
+					}
+				}
+				return false
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for DB Instance (%s) parameter group (%s) to be applied: %s", d.Id(), aws.StringValue(req.DBParameterGroupName), err)
+			}
+		}
+
+		// Options are applied asynchronously once the instance is available again.
+		if req.OptionGroupName != nil {
+			if aws.BoolValue(req.ApplyImmediately) {
+				log.Printf("[DEBUG] Waiting for DB Instance (%s) option group (%s) to be in-sync", d.Id(), aws.StringValue(req.OptionGroupName))
+				optionGroupName := aws.StringValue(req.OptionGroupName)
+				err = waitUntilAwsDbInstanceIsUpdated(d.Id(), conn, d.Timeout(schema.TimeoutUpdate), func(v *rds.DBInstance) bool {
+					return dbInstanceOptionGroupMembershipStatus(v, optionGroupName) == "in-sync"
+				})
+				// Options which can only be applied after a reboot stay in
+				// pending-apply until the instance is rebooted.
+				if isResourceTimeoutError(err) {
+					if v, rErr := resourceAwsDbInstanceRetrieve(d.Id(), conn); rErr == nil && v != nil && dbInstanceOptionGroupMembershipStatus(v, optionGroupName) == "pending-apply" {
+						return fmt.Errorf("error waiting for DB Instance (%s) option group (%s) to be in-sync: still pending-apply, the DB instance may require a reboot: %s", d.Id(), optionGroupName, err)
+					}
+				}
+				if err != nil {
+					return fmt.Errorf("error waiting for DB Instance (%s) option group (%s) to be in-sync: %s", d.Id(), aws.StringValue(req.OptionGroupName), err)
+				}
+			} else {
+				log.Printf("[INFO] DB Instance (%s) option group (%s) will be applied in the next maintenance window", d.Id(), aws.StringValue(req.OptionGroupName))
+			}
+		}
 	}
 
 	// separate request to promote a database
@@ -1660,6 +2808,11 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 				opts.PreferredBackupWindow = aws.String(attr.(string))
 			}
 			_, err := conn.PromoteReadReplica(&opts)
+			// The replica may already have been promoted by RDS if its source was deleted.
+			if isAWSErr(err, rds.ErrCodeInvalidDBInstanceStateFault, "not a read replica") {
+				log.Printf("[WARN] DB Instance (%s) is already promoted: %s", d.Id(), err)
+				err = nil
+			}
 			if err != nil {
 				return fmt.Errorf("Error promoting database: %#v", err)
 			}
@@ -1669,15 +2822,27 @@ func resourceAwsDbInstanceUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	if d.HasChange("tags") {
-		o, n := d.GetChange("tags")
+	if d.HasChange("tags_all") {
+		o, n := d.GetChange("tags_all")
 
+		// All added and removed tags are sent in a single
+		// AddTagsToResource and RemoveTagsFromResource call respectively.
 		if err := keyvaluetags.RdsUpdateTags(conn, d.Get("arn").(string), o, n); err != nil {
 			return fmt.Errorf("error updating RDS DB Instance (%s) tags: %s", d.Get("arn").(string), err)
 		}
 
 	}
 
+	if deleteMonitoringRoleARN != "" {
+		if err := deleteDbInstanceMonitoringRole(meta.(*AWSClient).iamconn, meta.(*AWSClient).partition, deleteMonitoringRoleARN); err != nil {
+			return err
+		}
+
+		if d.Get("monitoring_role_arn").(string) == deleteMonitoringRoleARN {
+			d.Set("monitoring_role_arn", "")
+		}
+	}
+
 	return resourceAwsDbInstanceRead(d, meta)
 }
 
@@ -1707,6 +2872,57 @@ func resourceAwsDbInstanceRetrieve(id string, conn *rds.RDS) (*rds.DBInstance, e
 	return resp.DBInstances[0], nil
 }
 
+// dbInstanceReplicaSourceCACertificateIdentifier returns the CA certificate
+// identifier of a replica's source DB instance. Sources in other regions are
+// not visible to the connection and return an empty identifier.
+func dbInstanceReplicaSourceCACertificateIdentifier(conn *rds.RDS, region, sourceDb string) (string, error) {
+	source, err := dbInstanceReplicaSource(conn, region, sourceDb)
+	if err != nil {
+		return "", err
+	}
+
+	if source == nil {
+		return "", nil
+	}
+
+	return aws.StringValue(source.CACertificateIdentifier), nil
+}
+
+// dbInstanceReplicaSource returns the source DB instance of a read replica,
+// given as an identifier or ARN. It returns nil if the source is in another
+// region or does not exist.
+func dbInstanceReplicaSource(conn *rds.RDS, region, sourceDb string) (*rds.DBInstance, error) {
+	sourceIdentifier := sourceDb
+
+	if parsedARN, err := arn.Parse(sourceDb); err == nil {
+		if parsedARN.Region != region {
+			return nil, nil
+		}
+		sourceIdentifier = strings.TrimPrefix(parsedARN.Resource, "db:")
+	}
+
+	return resourceAwsDbInstanceRetrieve(sourceIdentifier, conn)
+}
+
+// validateDbInstanceReplicaSourceBackups returns an error if the source DB
+// instance of a read replica has automated backups disabled, which RDS
+// requires to create the replica. Sources in another region or that cannot be
+// read are left for the API to validate.
+func validateDbInstanceReplicaSourceBackups(conn *rds.RDS, region, sourceDb string) error {
+	source, err := dbInstanceReplicaSource(conn, region, sourceDb)
+
+	if err != nil {
+		log.Printf("[WARN] Unable to validate DB Instance replicate_source_db (%s): %s", sourceDb, err)
+		return nil
+	}
+
+	if source == nil || aws.Int64Value(source.BackupRetentionPeriod) > 0 {
+		return nil
+	}
+
+	return fmt.Errorf("replicate_source_db (%s) has automated backups disabled; set backup_retention_period greater than 0 on the source DB instance to create a read replica", sourceDb)
+}
+
 func resourceAwsDbInstanceImport(
 	d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	// Neither skip_final_snapshot nor final_snapshot_identifier can be fetched
@@ -1714,6 +2930,12 @@ func resourceAwsDbInstanceImport(
 	// that final_snapshot_identifier is not required
 	d.Set("skip_final_snapshot", true)
 	d.Set("delete_automated_backups", true)
+	d.Set("force_destroy", false)
+	// allow_major_version_upgrade only applies to modifications and is not
+	// returned by the API, so default it like a configuration omitting it.
+	d.Set("allow_major_version_upgrade", false)
+	// An existing enhanced monitoring role is not managed by Terraform.
+	d.Set("create_monitoring_role", false)
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -1738,65 +2960,1209 @@ func resourceAwsDbInstanceStateRefreshFunc(id string, conn *rds.RDS) resource.St
 	}
 }
 
-func buildCloudwatchLogsExportConfiguration(d *schema.ResourceData) *rds.CloudwatchLogsExportConfiguration {
+// validateDbInstanceCloudwatchLogsExports returns an error if any of the given
+// CloudWatch log types cannot be exported for the engine. Engines without a
+// known set of log types (e.g. aurora* engines, which export logs at the cluster
+// level, custom-* engines, or an empty engine on a replica whose engine is
+// inherited from its source) are intentionally left for the API to validate.
+func validateDbInstanceCloudwatchLogsExports(engine string, logTypes []string) error {
+	var supported []string
+
+	switch {
+	case engine == "mariadb", engine == "mysql":
+		supported = []string{"audit", "error", "general", "slowquery"}
+	case engine == "postgres":
+		supported = []string{"postgresql", "upgrade"}
+	case strings.HasPrefix(engine, "oracle-"):
+		supported = []string{"alert", "audit", "listener", "trace"}
+	case strings.HasPrefix(engine, "sqlserver-"):
+		supported = []string{"agent", "error"}
+	default:
+		return nil
+	}
 
-	oraw, nraw := d.GetChange("enabled_cloudwatch_logs_exports")
-	o := oraw.([]interface{})
-	n := nraw.([]interface{})
+	var unsupported []string
+	for _, logType := range logTypes {
+		found := false
+		for _, supportedLogType := range supported {
+			if logType == supportedLogType {
+				found = true
+				break
+			}
+		}
 
-	create, disable := diffCloudwatchLogsExportConfiguration(o, n)
+		if !found {
+			unsupported = append(unsupported, logType)
+		}
+	}
 
-	return &rds.CloudwatchLogsExportConfiguration{
-		EnableLogTypes:  expandStringList(create),
-		DisableLogTypes: expandStringList(disable),
+	if len(unsupported) > 0 {
+		return fmt.Errorf("enabled_cloudwatch_logs_exports %q not supported with engine %q, expected one of %q", unsupported, engine, supported)
 	}
+
+	return nil
 }
 
-func diffCloudwatchLogsExportConfiguration(old, new []interface{}) ([]interface{}, []interface{}) {
-	create := make([]interface{}, 0)
-	disable := make([]interface{}, 0)
+// validateDbInstanceSecurityGroupNamesPlatforms returns an error if DB security
+// groups are used where the account only supports VPC, which the API otherwise
+// reports with an EC2-Classic specific fault. Unknown platforms, e.g. when
+// skip_get_ec2_platforms is set, are not validated.
+func validateDbInstanceSecurityGroupNamesPlatforms(platforms []string) error {
+	if len(platforms) == 0 || hasEc2Classic(platforms) {
+		return nil
+	}
 
-	for _, n := range new {
-		if _, contains := sliceContainsString(old, n.(string)); !contains {
-			create = append(create, n)
-		}
+	return fmt.Errorf("security_group_names: DB security groups are only supported on EC2-Classic, which this account does not support in this region (supported platforms: %q); use vpc_security_group_ids and db_subnet_group_name instead", platforms)
+}
+
+const dbInstanceMonitoringRoleAssumeRolePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "monitoring.rds.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}`
+
+// dbInstanceMonitoringRolePolicyARN returns the ARN of the AWS managed policy
+// which allows RDS to publish enhanced monitoring metrics to CloudWatch Logs.
+func dbInstanceMonitoringRolePolicyARN(partition string) string {
+	return arn.ARN{
+		Partition: partition,
+		Service:   "iam",
+		AccountID: "aws",
+		Resource:  "policy/service-role/AmazonRDSEnhancedMonitoringRole",
+	}.String()
+}
+
+// createDbInstanceMonitoringRole creates the IAM role used by RDS to publish
+// enhanced monitoring metrics for create_monitoring_role and returns its ARN.
+func createDbInstanceMonitoringRole(conn *iam.IAM, partition, identifier string) (string, error) {
+	roleName := resource.PrefixedUniqueId("tf-rds-monitoring-")
+
+	output, err := conn.CreateRole(&iam.CreateRoleInput{
+		AssumeRolePolicyDocument: aws.String(dbInstanceMonitoringRoleAssumeRolePolicy),
+		Description:              aws.String(fmt.Sprintf("Enhanced monitoring role for RDS DB Instance %s", identifier)),
+		RoleName:                 aws.String(roleName),
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("error creating DB Instance (%s) enhanced monitoring IAM Role: %s", identifier, err)
 	}
 
-	for _, o := range old {
-		if _, contains := sliceContainsString(new, o.(string)); !contains {
-			disable = append(disable, o)
+	_, err = conn.AttachRolePolicy(&iam.AttachRolePolicyInput{
+		PolicyArn: aws.String(dbInstanceMonitoringRolePolicyARN(partition)),
+		RoleName:  aws.String(roleName),
+	})
+
+	if err != nil {
+		if _, err := conn.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String(roleName)}); err != nil {
+			log.Printf("[WARN] error deleting IAM Role (%s): %s", roleName, err)
 		}
+
+		return "", fmt.Errorf("error attaching policy to DB Instance (%s) enhanced monitoring IAM Role (%s): %s", identifier, roleName, err)
 	}
 
-	return create, disable
+	return aws.StringValue(output.Role.Arn), nil
 }
 
-// Database instance status: http://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Overview.DBInstance.Status.html
-var resourceAwsDbInstanceCreatePendingStates = []string{
-	"backing-up",
-	"configuring-enhanced-monitoring",
-	"configuring-iam-database-auth",
-	"configuring-log-exports",
-	"creating",
-	"maintenance",
-	"modifying",
-	"rebooting",
-	"renaming",
-	"resetting-master-credentials",
-	"starting",
-	"stopping",
-	"upgrading",
+// deleteDbInstanceMonitoringRole deletes an IAM role created by
+// createDbInstanceMonitoringRole. Roles which no longer exist are ignored.
+func deleteDbInstanceMonitoringRole(conn *iam.IAM, partition, roleARN string) error {
+	parsedARN, err := arn.Parse(roleARN)
+
+	if err != nil {
+		return fmt.Errorf("error parsing enhanced monitoring IAM Role ARN (%s): %s", roleARN, err)
+	}
+
+	roleName := strings.TrimPrefix(parsedARN.Resource, "role/")
+
+	_, err = conn.DetachRolePolicy(&iam.DetachRolePolicyInput{
+		PolicyArn: aws.String(dbInstanceMonitoringRolePolicyARN(partition)),
+		RoleName:  aws.String(roleName),
+	})
+
+	if err != nil && !isAWSErr(err, iam.ErrCodeNoSuchEntityException, "") {
+		return fmt.Errorf("error detaching policy from enhanced monitoring IAM Role (%s): %s", roleName, err)
+	}
+
+	_, err = conn.DeleteRole(&iam.DeleteRoleInput{
+		RoleName: aws.String(roleName),
+	})
+
+	if err != nil && !isAWSErr(err, iam.ErrCodeNoSuchEntityException, "") {
+		return fmt.Errorf("error deleting enhanced monitoring IAM Role (%s): %s", roleName, err)
+	}
+
+	return nil
 }
 
-var resourceAwsDbInstanceDeletePendingStates = []string{
-	"available",
-	"backing-up",
-	"configuring-enhanced-monitoring",
-	"configuring-log-exports",
-	"creating",
-	"deleting",
-	"incompatible-parameters",
-	"modifying",
+// validateDbInstancePerformanceInsights returns an error if no orderable DB
+// instance option for the engine, engine version and instance class supports
+// Performance Insights. An engine version prefix, e.g. 5.7, matches all minor
+// versions. Without any matching options the configuration is left to the API.
+func validateDbInstancePerformanceInsights(instanceOptions []*rds.OrderableDBInstanceOption, engine, engineVersion, instanceClass string) error {
+	var found bool
+
+	for _, instanceOption := range instanceOptions {
+		if instanceOption == nil {
+			continue
+		}
+
+		if !strings.EqualFold(aws.StringValue(instanceOption.Engine), engine) || aws.StringValue(instanceOption.DBInstanceClass) != instanceClass {
+			continue
+		}
+
+		if v := aws.StringValue(instanceOption.EngineVersion); engineVersion != "" && v != engineVersion && !strings.HasPrefix(v, engineVersion+".") {
+			continue
+		}
+
+		if aws.BoolValue(instanceOption.SupportsPerformanceInsights) {
+			return nil
+		}
+
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	if engineVersion != "" {
+		return fmt.Errorf("performance_insights_enabled: Performance Insights is not supported for engine (%s) version (%s) with instance class (%s)", engine, engineVersion, instanceClass)
+	}
+
+	return fmt.Errorf("performance_insights_enabled: Performance Insights is not supported for engine (%s) with instance class (%s)", engine, instanceClass)
+}
+
+// dbInstanceDatabaseName returns the configured database name from db_name or
+// its alias name.
+func dbInstanceDatabaseName(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("db_name"); ok {
+		return v.(string)
+	}
+
+	return d.Get("name").(string)
+}
+
+// dbInstanceOptionGroupPropagationTimeout is how long creating a DB instance is
+// retried while a newly created option group is not yet usable.
+var dbInstanceOptionGroupPropagationTimeout = 2 * time.Minute
+
+// isDbInstanceOptionGroupPropagationError returns whether the error is the
+// InvalidParameterCombination error returned when an option group created
+// just before the DB instance has not propagated yet.
+func isDbInstanceOptionGroupPropagationError(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok || awsErr.Code() != "InvalidParameterCombination" {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(awsErr.Message()), "option group")
+}
+
+// retryDbInstanceOptionGroupPropagation calls f, retrying option group
+// propagation errors when an option group is configured.
+func retryDbInstanceOptionGroupPropagation(optionGroupName string, f func() error) error {
+	if optionGroupName == "" {
+		return f()
+	}
+
+	err := resource.Retry(dbInstanceOptionGroupPropagationTimeout, func() *resource.RetryError {
+		err := f()
+
+		if isDbInstanceOptionGroupPropagationError(err) {
+			log.Printf("[DEBUG] Retrying DB Instance creation while option group (%s) propagates: %s", optionGroupName, err)
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		err = f()
+	}
+
+	return err
+}
+
+// validateDbInstanceDomainIamRole returns an error if the IAM role used to join
+// the DB instance to a Directory Service domain does not exist or cannot be
+// assumed by RDS, which the API otherwise only reports late in the apply. The
+// check is skipped if the role cannot be read, e.g. without iam:GetRole.
+func validateDbInstanceDomainIamRole(conn *iam.IAM, roleName string) error {
+	trusted, err := dbInstanceIamRoleTrustsService(conn, roleName, "directoryservice.rds.amazonaws.com")
+
+	if isAWSErr(err, iam.ErrCodeNoSuchEntityException, "") {
+		return fmt.Errorf("domain_iam_role_name (%s): IAM role not found", roleName)
+	}
+
+	if err != nil {
+		log.Printf("[WARN] Unable to validate domain_iam_role_name (%s): %s", roleName, err)
+		return nil
+	}
+
+	if !trusted {
+		return fmt.Errorf("domain_iam_role_name (%s): IAM role assume role policy must trust the directoryservice.rds.amazonaws.com service", roleName)
+	}
+
+	return nil
+}
+
+// dbInstanceIamRoleTrustsService returns whether the assume role policy of the
+// IAM role trusts the service. A role that is not found or does not trust the
+// service yet is read again until IAM changes have propagated, as it may have
+// been created or updated in the same apply.
+func dbInstanceIamRoleTrustsService(conn *iam.IAM, roleName, service string) (bool, error) {
+	input := &iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	}
+
+	trustsService := func() (bool, error) {
+		output, err := conn.GetRole(input)
+
+		if err != nil {
+			return false, err
+		}
+
+		if output == nil || output.Role == nil {
+			return false, fmt.Errorf("error reading IAM Role (%s): empty response", roleName)
+		}
+
+		trusted, err := iamRolePolicyTrustsService(aws.StringValue(output.Role.AssumeRolePolicyDocument), service)
+
+		if err != nil {
+			return false, fmt.Errorf("error reading IAM Role (%s) assume role policy: %w", roleName, err)
+		}
+
+		return trusted, nil
+	}
+
+	var trusted bool
+
+	err := resource.Retry(iamwaiter.PropagationTimeout, func() *resource.RetryError {
+		var err error
+		trusted, err = trustsService()
+
+		if isAWSErr(err, iam.ErrCodeNoSuchEntityException, "") {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if !trusted {
+			return resource.RetryableError(fmt.Errorf("IAM Role (%s) assume role policy does not trust the %s service", roleName, service))
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		trusted, err = trustsService()
+	}
+
+	return trusted, err
+}
+
+// validateDbInstanceS3ImportIngestionRole returns an error if the IAM role
+// used to read the S3 import does not exist or cannot be assumed by RDS. Missing
+// S3 read permissions are only logged, as a bucket policy can still grant them.
+// The checks are skipped if the caller is not permitted to make them.
+func validateDbInstanceS3ImportIngestionRole(conn *iam.IAM, roleArn, bucketName, bucketPrefix string) error {
+	parsedArn, err := arn.Parse(roleArn)
+
+	if err != nil {
+		return fmt.Errorf("s3_import ingestion_role (%s): %s", roleArn, err)
+	}
+
+	roleName, err := iamRoleNameFromArn(parsedArn)
+
+	if err != nil {
+		return fmt.Errorf("s3_import ingestion_role (%s): %s", roleArn, err)
+	}
+
+	trusted, err := dbInstanceIamRoleTrustsService(conn, roleName, "rds.amazonaws.com")
+
+	if isAWSErr(err, iam.ErrCodeNoSuchEntityException, "") {
+		return fmt.Errorf("s3_import ingestion_role (%s): IAM role not found", roleArn)
+	}
+
+	if isAWSErr(err, "AccessDenied", "") {
+		log.Printf("[WARN] Unable to validate s3_import ingestion_role (%s): %s", roleArn, err)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading IAM Role (%s) for s3_import ingestion_role: %s", roleArn, err)
+	}
+
+	if !trusted {
+		return fmt.Errorf("s3_import ingestion_role (%s): IAM role assume role policy must trust the rds.amazonaws.com service", roleArn)
+	}
+
+	bucketArn := arn.ARN{
+		Partition: parsedArn.Partition,
+		Service:   "s3",
+		Resource:  bucketName,
+	}.String()
+
+	permissions := []struct {
+		Action      string
+		ResourceArn string
+	}{
+		{Action: "s3:ListBucket", ResourceArn: bucketArn},
+		{Action: "s3:GetObject", ResourceArn: fmt.Sprintf("%s/%s*", bucketArn, bucketPrefix)},
+	}
+
+	for _, permission := range permissions {
+		simulateOutput, err := conn.SimulatePrincipalPolicy(&iam.SimulatePrincipalPolicyInput{
+			ActionNames:     aws.StringSlice([]string{permission.Action}),
+			PolicySourceArn: aws.String(roleArn),
+			ResourceArns:    aws.StringSlice([]string{permission.ResourceArn}),
+		})
+
+		if err != nil {
+			log.Printf("[WARN] Unable to validate s3_import ingestion_role (%s) S3 permissions: %s", roleArn, err)
+			return nil
+		}
+
+		for _, result := range simulateOutput.EvaluationResults {
+			if decision := aws.StringValue(result.EvalDecision); decision != iam.PolicyEvaluationDecisionTypeAllowed {
+				log.Printf("[WARN] s3_import ingestion_role (%s) IAM policies do not allow %s on %s (%s), RDS may be unable to read the S3 import", roleArn, permission.Action, permission.ResourceArn, decision)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateDbInstanceMonitoringRoleArnRequired returns an error if Enhanced
+// Monitoring is enabled without a role, which is only optional when it is
+// created by create_monitoring_role.
+func validateDbInstanceMonitoringRoleArnRequired(monitoringInterval int, monitoringRoleArn string, createMonitoringRole bool) error {
+	if monitoringInterval == 0 || monitoringRoleArn != "" || createMonitoringRole {
+		return nil
+	}
+
+	return fmt.Errorf("monitoring_role_arn is required when monitoring_interval (%d) is greater than 0, unless create_monitoring_role is true", monitoringInterval)
+}
+
+// validateDbInstanceMonitoringRole returns an error if the IAM role used for
+// Enhanced Monitoring does not exist or cannot be assumed by the RDS monitoring
+// service. The check is skipped if the caller is not permitted to read the role.
+func validateDbInstanceMonitoringRole(conn *iam.IAM, roleArn string) error {
+	parsedArn, err := arn.Parse(roleArn)
+
+	if err != nil {
+		return fmt.Errorf("monitoring_role_arn (%s): %s", roleArn, err)
+	}
+
+	roleName, err := iamRoleNameFromArn(parsedArn)
+
+	if err != nil {
+		return fmt.Errorf("monitoring_role_arn (%s): %s", roleArn, err)
+	}
+
+	trusted, err := dbInstanceIamRoleTrustsService(conn, roleName, "monitoring.rds.amazonaws.com")
+
+	if isAWSErr(err, iam.ErrCodeNoSuchEntityException, "") {
+		return fmt.Errorf("monitoring_role_arn (%s): IAM role not found", roleArn)
+	}
+
+	if isAWSErr(err, "AccessDenied", "") {
+		log.Printf("[WARN] Unable to validate monitoring_role_arn (%s): %s", roleArn, err)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading IAM Role (%s) for monitoring_role_arn: %s", roleArn, err)
+	}
+
+	if !trusted {
+		return fmt.Errorf("monitoring_role_arn (%s): IAM role assume role policy must trust the monitoring.rds.amazonaws.com service", roleArn)
+	}
+
+	return nil
+}
+
+// iamRoleNameFromArn returns the name of the IAM role with the given ARN,
+// without any path.
+func iamRoleNameFromArn(parsedArn arn.ARN) (string, error) {
+	if parsedArn.Service != "iam" || !strings.HasPrefix(parsedArn.Resource, "role/") {
+		return "", fmt.Errorf("expected an IAM role ARN")
+	}
+
+	return parsedArn.Resource[strings.LastIndex(parsedArn.Resource, "/")+1:], nil
+}
+
+// iamRolePolicyTrustsService returns whether the (URL encoded) assume role
+// policy document names the given service principal.
+func iamRolePolicyTrustsService(document, service string) (bool, error) {
+	policy, err := url.QueryUnescape(document)
+
+	if err != nil {
+		return false, err
+	}
+
+	return strings.Contains(policy, fmt.Sprintf("%q", service)), nil
+}
+
+// validateDbInstanceSqlServerMultiAz returns an error if Multi-AZ is enabled on
+// a SQL Server DB instance without automated backups, which mirroring requires.
+func validateDbInstanceSqlServerMultiAz(engine string, multiAz bool, backupRetentionPeriod int) error {
+	if !multiAz || !strings.HasPrefix(engine, "sqlserver") {
+		return nil
+	}
+
+	if backupRetentionPeriod == 0 {
+		return fmt.Errorf("backup_retention_period must be greater than 0 when multi_az is enabled with engine %q", engine)
+	}
+
+	return nil
+}
+
+// dbInstanceSqlServerReservedPorts are ports SQL Server DB instances cannot
+// listen on, as they are used by Windows or RDS management.
+// https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/USER_CreateDBInstance.Settings.html
+var dbInstanceSqlServerReservedPorts = []int{1234, 1434, 3260, 3343, 3389, 47001, 49152, 49153, 49154, 49155, 49156}
+
+// validateDbInstancePort returns an error if the port is outside the range
+// allowed for all engines or is reserved by the engine. A zero port is unset and
+// uses the engine default.
+func validateDbInstancePort(engine string, port int) error {
+	if port == 0 {
+		return nil
+	}
+
+	if port < 1150 || port > 65535 {
+		return fmt.Errorf("port must be between 1150 and 65535, got %d", port)
+	}
+
+	if !strings.HasPrefix(engine, "sqlserver") {
+		return nil
+	}
+
+	for _, reserved := range dbInstanceSqlServerReservedPorts {
+		if port == reserved {
+			return fmt.Errorf("port %d is reserved and cannot be used with engine %q", port, engine)
+		}
+	}
+
+	return nil
+}
+
+// dbInstanceMinimumAllocatedStorage returns the smallest allocated storage in
+// GiB RDS accepts for the engine, or 0 if it is unknown.
+// https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/CHAP_Storage.html
+func dbInstanceMinimumAllocatedStorage(engine string) int {
+	switch {
+	case engine == "mariadb", engine == "mysql", engine == "postgres":
+		return 5
+	case strings.HasPrefix(engine, "oracle"):
+		return 10
+	case strings.HasPrefix(engine, "sqlserver"):
+		return 20
+	}
+
+	return 0
+}
+
+// validateDbInstanceAllocatedStorage returns an error if the allocated storage
+// is below the minimum of the engine. Unset values are inherited or use the
+// engine default.
+func validateDbInstanceAllocatedStorage(engine string, allocatedStorage int) error {
+	if allocatedStorage == 0 {
+		return nil
+	}
+
+	if minimum := dbInstanceMinimumAllocatedStorage(engine); allocatedStorage < minimum {
+		return fmt.Errorf("allocated_storage must be at least %d GiB for engine %q, got %d", minimum, engine, allocatedStorage)
+	}
+
+	return nil
+}
+
+// dbInstanceReservedUsernames returns the master usernames RDS rejects for the
+// engine, as they are used by RDS or the engine itself.
+func dbInstanceReservedUsernames(engine string) []string {
+	usernames := []string{"rdsadmin"}
+
+	switch {
+	case engine == "mariadb", engine == "mysql", engine == "postgres":
+		usernames = append(usernames, "rdsrepladmin")
+	case strings.HasPrefix(engine, "oracle"):
+		usernames = append(usernames, "sys", "system")
+	case strings.HasPrefix(engine, "sqlserver"):
+		usernames = append(usernames, "rdsa", "sa")
+	}
+
+	return usernames
+}
+
+// validateDbInstanceUsername returns an error if the master username is
+// reserved by the engine. An empty username is inherited or left for the API
+// to validate.
+func validateDbInstanceUsername(engine, username string) error {
+	if username == "" {
+		return nil
+	}
+
+	for _, reserved := range dbInstanceReservedUsernames(engine) {
+		if strings.EqualFold(username, reserved) {
+			return fmt.Errorf("username %q is reserved and cannot be used as the master username with engine %q", username, engine)
+		}
+	}
+
+	return nil
+}
+
+// validateDbInstanceStorage returns an error if the combination of storage
+// type, allocated storage and Provisioned IOPS is invalid: standard and gp2
+// storage take no iops, while io1 storage requires iops within the ratio RDS
+// allows, unless they are inherited from a source DB instance or snapshot. An
+// empty storage type is left for RDS to default, which is io1 when iops are set.
+func validateDbInstanceStorage(engine, storageType string, allocatedStorage, iops int, inherited bool) error {
+	if storageType == "" && iops != 0 {
+		storageType = "io1"
+	}
+
+	switch storageType {
+	case "":
+		return nil
+	case "standard", "gp2":
+		return validateDbInstanceIopsStorageType(storageType, iops)
+	case "io1":
+		if iops == 0 {
+			if inherited {
+				return nil
+			}
+
+			return fmt.Errorf("iops must be set with storage_type io1")
+		}
+
+		return validateDbInstanceIopsRatio(engine, allocatedStorage, iops)
+	}
+
+	return fmt.Errorf("storage_type must be one of standard, gp2 or io1, got %q", storageType)
+}
+
+// validateDbInstanceIopsStorageType returns an error if Provisioned IOPS are
+// set with a storage type other than io1, e.g. when switching to gp2 without
+// removing iops.
+func validateDbInstanceIopsStorageType(storageType string, iops int) error {
+	if iops == 0 || storageType == "" || storageType == "io1" {
+		return nil
+	}
+
+	return fmt.Errorf("iops can only be set with storage_type io1, got storage_type %q; remove iops when switching to another storage type", storageType)
+}
+
+// validateDbInstanceIopsRatio returns an error if the ratio of Provisioned
+// IOPS to allocated storage (in GiB) of io1 storage is outside the range RDS
+// allows: 1:2 to 50:1, or 1:1 to 50:1 for SQL Server. Unset values are left for
+// the API to validate.
+// https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/CHAP_Storage.html#USER_PIOPS
+func validateDbInstanceIopsRatio(engine string, allocatedStorage, iops int) error {
+	if allocatedStorage == 0 || iops == 0 {
+		return nil
+	}
+
+	if iops > 50*allocatedStorage {
+		return fmt.Errorf("iops (%d) must be at most 50 times allocated_storage (%d) for io1 storage", iops, allocatedStorage)
+	}
+
+	if strings.HasPrefix(engine, "sqlserver") {
+		if iops < allocatedStorage {
+			return fmt.Errorf("iops (%d) must be at least allocated_storage (%d) for io1 storage with engine %q", iops, allocatedStorage, engine)
+		}
+
+		return nil
+	}
+
+	if 2*iops < allocatedStorage {
+		return fmt.Errorf("iops (%d) must be at least half of allocated_storage (%d) for io1 storage", iops, allocatedStorage)
+	}
+
+	return nil
+}
+
+// dbInstanceMaxAllocatedStorageDisabledEquivalent returns whether a
+// max_allocated_storage difference is between two ways of disabling storage
+// autoscaling. Configuring a maximum equal to the allocated storage disables
+// it, after which the API returns no maximum (0).
+func dbInstanceMaxAllocatedStorageDisabledEquivalent(old, new string, allocatedStorage int) bool {
+	return old == "0" && new == strconv.Itoa(allocatedStorage)
+}
+
+// validateDbInstanceEngine rejects Aurora engines, whose DB instances are
+// members of a DB cluster and are managed with aws_rds_cluster_instance.
+func validateDbInstanceEngine(v interface{}, k string) (ws []string, errors []error) {
+	engine := strings.ToLower(v.(string))
+
+	if engine == "aurora" || strings.HasPrefix(engine, "aurora-") {
+		errors = append(errors, fmt.Errorf("%q (%s) is an Aurora engine, which is not supported by aws_db_instance; use aws_rds_cluster with aws_rds_cluster_instance instead", k, v.(string)))
+	}
+
+	return
+}
+
+// validateDbInstanceParameterGroupExists returns an error if the DB parameter
+// group does not exist. Other errors, e.g. missing permissions, are logged and
+// left for the API to surface when the group is used.
+func validateDbInstanceParameterGroupExists(conn *rds.RDS, region, name string) error {
+	_, err := conn.DescribeDBParameterGroups(&rds.DescribeDBParameterGroupsInput{
+		DBParameterGroupName: aws.String(name),
+	})
+
+	if isAWSErr(err, rds.ErrCodeDBParameterGroupNotFoundFault, "") {
+		return fmt.Errorf("parameter_group_name (%s) does not exist in region (%s); create the DB parameter group (e.g. with aws_db_parameter_group) or use an existing one", name, region)
+	}
+
+	if err != nil {
+		log.Printf("[WARN] Unable to validate DB Instance parameter_group_name (%s): %s", name, err)
+	}
+
+	return nil
+}
+
+// validateDbInstanceAvailabilityZone returns an error if the availability zone
+// has no subnet in the DB subnet group. Other errors, e.g. missing permissions
+// or a DB subnet group that does not exist, are logged and left for the API to
+// surface.
+func validateDbInstanceAvailabilityZone(conn *rds.RDS, availabilityZone, dbSubnetGroupName string) error {
+	output, err := conn.DescribeDBSubnetGroups(&rds.DescribeDBSubnetGroupsInput{
+		DBSubnetGroupName: aws.String(dbSubnetGroupName),
+	})
+
+	if err != nil {
+		log.Printf("[WARN] Unable to validate DB Instance availability_zone (%s): error reading DB Subnet Group (%s): %s", availabilityZone, dbSubnetGroupName, err)
+		return nil
+	}
+
+	for _, dbSubnetGroup := range output.DBSubnetGroups {
+		if dbSubnetGroup != nil && aws.StringValue(dbSubnetGroup.DBSubnetGroupName) == dbSubnetGroupName {
+			return validateDbInstanceAvailabilityZoneInSubnetGroup(availabilityZone, dbSubnetGroup)
+		}
+	}
+
+	return nil
+}
+
+// validateDbInstanceAvailabilityZoneInSubnetGroup returns an error listing the
+// DB subnet group's availability zones if availabilityZone is not one of them.
+func validateDbInstanceAvailabilityZoneInSubnetGroup(availabilityZone string, dbSubnetGroup *rds.DBSubnetGroup) error {
+	var availabilityZones []string
+
+	for _, subnet := range dbSubnetGroup.Subnets {
+		if subnet == nil || subnet.SubnetAvailabilityZone == nil {
+			continue
+		}
+
+		name := aws.StringValue(subnet.SubnetAvailabilityZone.Name)
+
+		if name == availabilityZone {
+			return nil
+		}
+
+		availabilityZones = append(availabilityZones, name)
+	}
+
+	if len(availabilityZones) == 0 {
+		return nil
+	}
+
+	sort.Strings(availabilityZones)
+
+	return fmt.Errorf("availability_zone (%s) has no subnet in db_subnet_group_name (%s), which covers: %s", availabilityZone, aws.StringValue(dbSubnetGroup.DBSubnetGroupName), strings.Join(availabilityZones, ", "))
+}
+
+// validateDbInstanceCACertificateIdentifier returns an error if the CA
+// certificate is not available in the region. The certificates are shared with
+// the aws_rds_certificates data source. Other errors, e.g. missing
+// permissions, are logged and left for the API to surface.
+func validateDbInstanceCACertificateIdentifier(conn *rds.RDS, cache *rdsCertificatesCache, region, identifier string) error {
+	certificates, err := cache.get(func() ([]*rds.Certificate, error) {
+		return describeRdsCertificates(conn)
+	})
+
+	if err != nil {
+		log.Printf("[WARN] Unable to validate DB Instance ca_cert_identifier (%s): %s", identifier, err)
+		return nil
+	}
+
+	return validateDbInstanceCACertificateIdentifierAvailable(region, identifier, certificates)
+}
+
+// validateDbInstanceCACertificateIdentifierAvailable returns an error listing
+// the available CA certificates if identifier is not one of them.
+func validateDbInstanceCACertificateIdentifierAvailable(region, identifier string, certificates []*rds.Certificate) error {
+	var identifiers []string
+
+	for _, certificate := range certificates {
+		if certificate == nil {
+			continue
+		}
+
+		if aws.StringValue(certificate.CertificateIdentifier) == identifier {
+			return nil
+		}
+
+		identifiers = append(identifiers, aws.StringValue(certificate.CertificateIdentifier))
+	}
+
+	// Without any certificates there is nothing to validate against.
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	sort.Strings(identifiers)
+
+	return fmt.Errorf("ca_cert_identifier (%s) is not available in region (%s); valid identifiers are: %s", identifier, region, strings.Join(identifiers, ", "))
+}
+
+// validateDbInstanceOptionGroup returns an error if the DB option group does
+// not exist or, when engineVersion is set, is for another major engine version.
+// Other errors are logged and left for the API to surface.
+func validateDbInstanceOptionGroup(conn *rds.RDS, region, name, engineVersion string) error {
+	output, err := conn.DescribeOptionGroups(&rds.DescribeOptionGroupsInput{
+		OptionGroupName: aws.String(name),
+	})
+
+	if isAWSErr(err, rds.ErrCodeOptionGroupNotFoundFault, "") {
+		return fmt.Errorf("option_group_name (%s) does not exist in region (%s); create the DB option group (e.g. with aws_db_option_group) or use an existing one", name, region)
+	}
+
+	if err != nil {
+		log.Printf("[WARN] Unable to validate DB Instance option_group_name (%s): %s", name, err)
+		return nil
+	}
+
+	if engineVersion == "" {
+		return nil
+	}
+
+	for _, optionGroup := range output.OptionGroupsList {
+		if optionGroup == nil || aws.StringValue(optionGroup.OptionGroupName) != name {
+			continue
+		}
+
+		return validateDbInstanceOptionGroupMajorEngineVersion(name, aws.StringValue(optionGroup.MajorEngineVersion), engineVersion)
+	}
+
+	return nil
+}
+
+// validateDbInstanceOptionGroupMajorEngineVersion returns an error if the
+// engine version does not belong to the major engine version of the option
+// group, e.g. after a major version upgrade.
+func validateDbInstanceOptionGroupMajorEngineVersion(name, majorEngineVersion, engineVersion string) error {
+	if majorEngineVersion == "" || dbInstanceEngineVersionHasMajorVersion(engineVersion, majorEngineVersion) {
+		return nil
+	}
+
+	return fmt.Errorf("option_group_name (%s) is for major engine version %s and cannot be used with engine_version %s; use an option group for the new major engine version (e.g. with aws_db_option_group)", name, majorEngineVersion, engineVersion)
+}
+
+// dbInstanceDefaultOptionGroupName returns the name of the default option
+// group for the engine and the major version of engineVersion.
+func dbInstanceDefaultOptionGroupName(conn *rds.RDS, engine, engineVersion string) (string, error) {
+	var name string
+
+	input := &rds.DescribeOptionGroupsInput{
+		EngineName: aws.String(engine),
+	}
+
+	err := conn.DescribeOptionGroupsPages(input, func(page *rds.DescribeOptionGroupsOutput, lastPage bool) bool {
+		for _, optionGroup := range page.OptionGroupsList {
+			if optionGroup == nil || !strings.HasPrefix(aws.StringValue(optionGroup.OptionGroupName), "default:") {
+				continue
+			}
+
+			if dbInstanceEngineVersionHasMajorVersion(engineVersion, aws.StringValue(optionGroup.MajorEngineVersion)) {
+				name = aws.StringValue(optionGroup.OptionGroupName)
+				return false
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("no default option group found for engine (%s) version (%s)", engine, engineVersion)
+	}
+
+	return name, nil
+}
+
+// dbInstanceEngineVersionHasMajorVersion returns whether engineVersion belongs
+// to majorVersion, e.g. 5.7.22 to 5.7 and 12.3 to 12, but not 5.7.22 to 5.
+func dbInstanceEngineVersionHasMajorVersion(engineVersion, majorVersion string) bool {
+	if majorVersion == "" {
+		return false
+	}
+
+	return engineVersion == majorVersion || strings.HasPrefix(engineVersion, majorVersion+".")
+}
+
+// validateDbInstanceReplicaMultiAz returns an error if Multi-AZ is enabled on a
+// read replica of an engine whose read replicas cannot be Multi-AZ. An empty
+// engine is inherited from the source and is left for the API to validate.
+func validateDbInstanceReplicaMultiAz(engine string, multiAz bool) error {
+	if !multiAz || engine == "" {
+		return nil
+	}
+
+	switch {
+	case engine == "mariadb", engine == "mysql", engine == "postgres", strings.HasPrefix(engine, "oracle"):
+		return nil
+	}
+
+	return fmt.Errorf("multi_az cannot be enabled on a read replica (replicate_source_db) with engine %q; Multi-AZ read replicas are supported for MariaDB, MySQL, Oracle and PostgreSQL", engine)
+}
+
+// validateDbInstancePerformanceInsightsKmsKeyId returns an error if a
+// Performance Insights KMS key is set while Performance Insights is disabled.
+func validateDbInstancePerformanceInsightsKmsKeyId(enabled bool, kmsKeyId string) error {
+	if enabled || kmsKeyId == "" {
+		return nil
+	}
+
+	return fmt.Errorf("performance_insights_kms_key_id (%s) can only be set when performance_insights_enabled is true", kmsKeyId)
+}
+
+// validateDbInstanceReplicaBackupRetentionPeriod returns an error if automated
+// backups are enabled on a read replica of an engine whose read replicas cannot
+// have automated backups. An empty engine is inherited from the source and is
+// left for the API to validate.
+// https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/USER_ReadRepl.html
+func validateDbInstanceReplicaBackupRetentionPeriod(engine string, backupRetentionPeriod int) error {
+	if backupRetentionPeriod == 0 || engine == "" {
+		return nil
+	}
+
+	switch engine {
+	case "mariadb", "mysql":
+		return nil
+	}
+
+	return fmt.Errorf("backup_retention_period must be 0 on a read replica (replicate_source_db) with engine %q; automated backups of read replicas are supported for MariaDB and MySQL", engine)
+}
+
+// expandDbInstanceRestoreToPointInTime sets the source DB instance and the
+// point in time to restore to on the RestoreDBInstanceToPointInTime input.
+func expandDbInstanceRestoreToPointInTime(tfMap map[string]interface{}, input *rds.RestoreDBInstanceToPointInTimeInput) error {
+	if v, ok := tfMap["restore_time"].(string); ok && v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("error parsing restore_to_point_in_time restore_time (%s): %w", v, err)
+		}
+		input.RestoreTime = aws.Time(t)
+	}
+
+	if v, ok := tfMap["source_db_instance_identifier"].(string); ok && v != "" {
+		input.SourceDBInstanceIdentifier = aws.String(v)
+	}
+
+	if v, ok := tfMap["use_latest_restorable_time"].(bool); ok && v {
+		input.UseLatestRestorableTime = aws.Bool(v)
+	}
+
+	if input.RestoreTime == nil && input.UseLatestRestorableTime == nil {
+		return fmt.Errorf("restore_to_point_in_time requires one of restore_time or use_latest_restorable_time")
+	}
+
+	return nil
+}
+
+// validateDbInstanceEngineVersionUpgrade returns an error if the new engine
+// version is older than the old one. A new version that is a prefix of the old
+// one (e.g. 5.7 and 5.7.22) is not a downgrade, and versions that cannot be
+// parsed are left for the API to validate. Major version upgrades are still
+// gated by allow_major_version_upgrade in the API.
+func validateDbInstanceEngineVersionUpgrade(old, new string) error {
+	if old == "" || new == "" || old == new || strings.HasPrefix(old, new+".") {
+		return nil
+	}
+
+	oldVersion, err := gversion.NewVersion(old)
+	if err != nil {
+		return nil
+	}
+
+	newVersion, err := gversion.NewVersion(new)
+	if err != nil {
+		return nil
+	}
+
+	if newVersion.LessThan(oldVersion) {
+		return fmt.Errorf("engine_version cannot be downgraded from %q to %q: RDS only supports upgrading the engine version; restore a DB snapshot into a new DB instance or export the data instead", old, new)
+	}
+
+	return nil
+}
+
+// validateDbInstanceSnapshotRestoreKmsKey returns an error if a DB instance
+// restored from the given snapshot cannot be encrypted with the given KMS key.
+// The restore API does not accept a KMS key, so the snapshot must already be
+// encrypted with it.
+func validateDbInstanceSnapshotRestoreKmsKey(snapshot *rds.DBSnapshot, kmsKeyID string) error {
+	snapshotID := aws.StringValue(snapshot.DBSnapshotIdentifier)
+
+	if !aws.BoolValue(snapshot.Encrypted) {
+		return fmt.Errorf("DB Snapshot (%s) is not encrypted and RDS does not support restoring an unencrypted DB snapshot into an encrypted DB instance; copy the snapshot with kms_key_id (%s) and restore from the copy instead", snapshotID, kmsKeyID)
+	}
+
+	if v := aws.StringValue(snapshot.KmsKeyId); v != kmsKeyID {
+		return fmt.Errorf("DB Snapshot (%s) is encrypted with KMS key (%s) and RDS does not support re-encrypting it while restoring; copy the snapshot with kms_key_id (%s) and restore from the copy instead", snapshotID, v, kmsKeyID)
+	}
+
+	return nil
+}
+
+// normalizeDbInstanceWindow lowercases backup_window and maintenance_window,
+// as the API stores and returns them in lowercase (e.g. sun:04:00-sun:05:00).
+func normalizeDbInstanceWindow(v interface{}) string {
+	value, ok := v.(string)
+	if !ok {
+		return ""
+	}
+
+	return strings.ToLower(value)
+}
+
+// suppressEquivalentDbInstanceWindow suppresses backup_window and
+// maintenance_window differences that only differ in case, e.g. for values
+// stored before they were normalized.
+func suppressEquivalentDbInstanceWindow(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// suppressDbInstanceMultiAzAvailabilityZoneDiff suppresses availability_zone
+// differences for existing Multi-AZ instances. AWS chooses the primary's
+// availability zone and moves it on failover, so it is not user-controllable.
+func suppressDbInstanceMultiAzAvailabilityZoneDiff(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && d.Get("multi_az").(bool)
+}
+
+// suppressDbInstanceReplicaInheritedEncryptionDiff suppresses storage_encrypted
+// and kms_key_id differences for existing read replicas when they are omitted
+// from the configuration, as same-region replicas inherit the encryption
+// settings and key of their source DB instance.
+func suppressDbInstanceReplicaInheritedEncryptionDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" || d.Get("replicate_source_db").(string) == "" {
+		return false
+	}
+
+	return new == "" || (k == "storage_encrypted" && new == "false")
+}
+
+// suppressDbInstanceDefaultOptionGroupDiff suppresses option_group_name
+// differences when it is omitted and the DB instance is a member of an engine
+// default option group (e.g. default:mysql-5-7), which RDS assigns when none is
+// specified. Read replicas omitting it keep the option group they were given.
+func suppressDbInstanceDefaultOptionGroupDiff(k, old, new string, d *schema.ResourceData) bool {
+	if new != "" {
+		return false
+	}
+
+	return strings.HasPrefix(old, "default:") || d.Get("replicate_source_db").(string) != ""
+}
+
+// suppressDbInstanceMonitoringRoleArnOmittedDiff suppresses monitoring_role_arn
+// differences when it is omitted from the configuration but known from the
+// API, e.g. a role created by create_monitoring_role or kept by RDS after
+// Enhanced Monitoring is disabled. An omitted role of a new DB instance is
+// empty rather than computed, so it can be validated at plan time.
+func suppressDbInstanceMonitoringRoleArnOmittedDiff(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && new == ""
+}
+
+// suppressDbInstancePerformanceInsightsDisabledDiff suppresses
+// performance_insights_retention_period differences while Performance Insights
+// is disabled, as the API then returns no retention period.
+func suppressDbInstancePerformanceInsightsDisabledDiff(k, old, new string, d *schema.ResourceData) bool {
+	return !d.Get("performance_insights_enabled").(bool)
+}
+
+// suppressEquivalentRdsSourceDbIdentifierAndARN suppresses differences between a
+// same-region source DB instance identifier and its ARN, as the API returns the
+// identifier for same-region replicas regardless of which form was configured.
+// The partition, region and account are taken from the replica's own ARN.
+func suppressEquivalentRdsSourceDbIdentifierAndARN(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+
+	instanceARN, err := arn.Parse(d.Get("arn").(string))
+	if err != nil {
+		return false
+	}
+
+	return rdsSourceDbInstanceIdentifierEquivalent(old, new, instanceARN.Partition, instanceARN.Region, instanceARN.AccountID)
+}
+
+// rdsSourceDbInstanceIdentifierEquivalent returns whether one value is a bare
+// DB instance identifier and the other is the ARN of that DB instance in the
+// given partition, region and account.
+func rdsSourceDbInstanceIdentifierEquivalent(old, new, partition, region, accountID string) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	identifier, v := old, new
+	if arn.IsARN(old) {
+		identifier, v = new, old
+	}
+
+	if arn.IsARN(identifier) {
+		return false
+	}
+
+	parsedARN, err := arn.Parse(v)
+	if err != nil {
+		return false
+	}
+
+	return parsedARN.Partition == partition &&
+		parsedARN.Service == rds.ServiceName &&
+		parsedARN.Region == region &&
+		parsedARN.AccountID == accountID &&
+		parsedARN.Resource == "db:"+identifier
+}
+
+func buildCloudwatchLogsExportConfiguration(d *schema.ResourceData) *rds.CloudwatchLogsExportConfiguration {
+
+	oraw, nraw := d.GetChange("enabled_cloudwatch_logs_exports")
+	o := oraw.([]interface{})
+	n := nraw.([]interface{})
+
+	create, disable := diffCloudwatchLogsExportConfiguration(o, n)
+
+	return &rds.CloudwatchLogsExportConfiguration{
+		EnableLogTypes:  expandStringList(create),
+		DisableLogTypes: expandStringList(disable),
+	}
+}
+
+func diffCloudwatchLogsExportConfiguration(old, new []interface{}) ([]interface{}, []interface{}) {
+	create := make([]interface{}, 0)
+	disable := make([]interface{}, 0)
+
+	for _, n := range new {
+		if _, contains := sliceContainsString(old, n.(string)); !contains {
+			create = append(create, n)
+		}
+	}
+
+	for _, o := range old {
+		if _, contains := sliceContainsString(new, o.(string)); !contains {
+			disable = append(disable, o)
+		}
+	}
+
+	return create, disable
+}
+
+func flattenDbInstancePendingModifiedValues(apiObject *rds.PendingModifiedValues) []interface{} {
+	if apiObject == nil {
+		return []interface{}{}
+	}
+
+	// The API returns an empty structure when nothing is pending.
+	if apiObject.AllocatedStorage == nil && apiObject.BackupRetentionPeriod == nil &&
+		apiObject.CACertificateIdentifier == nil && apiObject.EngineVersion == nil &&
+		apiObject.DBInstanceClass == nil && apiObject.Iops == nil &&
+		apiObject.MultiAZ == nil && apiObject.Port == nil && apiObject.StorageType == nil {
+		return []interface{}{}
+	}
+
+	tfMap := map[string]interface{}{
+		"allocated_storage":       aws.Int64Value(apiObject.AllocatedStorage),
+		"backup_retention_period": aws.Int64Value(apiObject.BackupRetentionPeriod),
+		"ca_cert_identifier":      aws.StringValue(apiObject.CACertificateIdentifier),
+		"engine_version":          aws.StringValue(apiObject.EngineVersion),
+		"instance_class":          aws.StringValue(apiObject.DBInstanceClass),
+		"iops":                    aws.Int64Value(apiObject.Iops),
+		"multi_az":                aws.BoolValue(apiObject.MultiAZ),
+		"port":                    aws.Int64Value(apiObject.Port),
+		"storage_type":            aws.StringValue(apiObject.StorageType),
+	}
+
+	return []interface{}{tfMap}
+}
+
+// dbInstanceCurrentOptionGroupMembership returns the option group membership
+// the DB instance is moving to or already uses. While an option group change is
+// applied, the API also returns the previous membership as being removed.
+func dbInstanceCurrentOptionGroupMembership(memberships []*rds.OptionGroupMembership) *rds.OptionGroupMembership {
+	var current *rds.OptionGroupMembership
+
+	for _, membership := range memberships {
+		if membership == nil {
+			continue
+		}
+
+		switch aws.StringValue(membership.Status) {
+		case "pending-removal", "removing":
+			if current == nil {
+				current = membership
+			}
+		default:
+			return membership
+		}
+	}
+
+	return current
+}
+
+// Database instance status: http://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/Overview.DBInstance.Status.html
+var resourceAwsDbInstanceCreatePendingStates = []string{
+	"backing-up",
+	"configuring-enhanced-monitoring",
+	"configuring-iam-database-auth",
+	"configuring-log-exports",
+	"creating",
+	"maintenance",
+	"modifying",
+	"rebooting",
+	"renaming",
+	"resetting-master-credentials",
+	"starting",
+	"stopping",
+	"upgrading",
+}
+
+// The DB instance is usable while storage-optimization runs after it is
+// created with or modified to a large amount of storage, which can take hours.
+var resourceAwsDbInstanceAvailableStates = []string{
+	"available",
+	"storage-optimization",
+}
+
+var resourceAwsDbInstanceDeletePendingStates = []string{
+	"available",
+	"backing-up",
+	"configuring-enhanced-monitoring",
+	"configuring-log-exports",
+	"creating",
+	"deleting",
+	"incompatible-parameters",
+	"modifying",
+	"rebooting",
 	"starting",
 	"stopping",
 	"storage-full",
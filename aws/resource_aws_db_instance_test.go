@@ -1,10 +1,13 @@
 package aws
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"testing"
 	"time"
 
@@ -14,7 +17,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/tfawsresource"
 )
 
 func init() {
@@ -64,9 +70,3142 @@ func testSweepDbInstances(region string) error {
 	return nil
 }
 
+func TestValidateDbInstanceCloudwatchLogsExports(t *testing.T) {
+	testCases := []struct {
+		Engine      string
+		LogTypes    []string
+		ExpectError bool
+	}{
+		{
+			Engine:   "mysql",
+			LogTypes: []string{"audit", "error", "general", "slowquery"},
+		},
+		{
+			Engine:   "mariadb",
+			LogTypes: []string{"audit"},
+		},
+		{
+			Engine:      "mysql",
+			LogTypes:    []string{"postgresql"},
+			ExpectError: true,
+		},
+		{
+			Engine:   "postgres",
+			LogTypes: []string{"postgresql", "upgrade"},
+		},
+		{
+			Engine:      "postgres",
+			LogTypes:    []string{"postgresql", "audit"},
+			ExpectError: true,
+		},
+		{
+			Engine:   "oracle-ee",
+			LogTypes: []string{"alert", "audit", "listener", "trace"},
+		},
+		{
+			Engine:      "oracle-se2",
+			LogTypes:    []string{"slowquery"},
+			ExpectError: true,
+		},
+		{
+			Engine:   "sqlserver-ex",
+			LogTypes: []string{"agent", "error"},
+		},
+		{
+			Engine:      "sqlserver-se",
+			LogTypes:    []string{"general"},
+			ExpectError: true,
+		},
+		{
+			// Engine not known at plan time (e.g. a replica inheriting
+			// the engine of its source) is intentionally skipped.
+			Engine:   "",
+			LogTypes: []string{"audit"},
+		},
+		{
+			Engine:   "aurora-postgresql",
+			LogTypes: []string{"audit"},
+		},
+		{
+			Engine:   "custom-oracle-ee",
+			LogTypes: []string{"general"},
+		},
+		{
+			Engine:   "postgres",
+			LogTypes: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceCloudwatchLogsExports(tc.Engine, tc.LogTypes)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for engine %q and log types %q", tc.Engine, tc.LogTypes)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for engine %q and log types %q: %s", tc.Engine, tc.LogTypes, err)
+		}
+	}
+}
+
+func TestRdsSourceDbInstanceIdentifierEquivalent(t *testing.T) {
+	testCases := []struct {
+		Old        string
+		New        string
+		Equivalent bool
+	}{
+		{
+			Old:        "source",
+			New:        "arn:aws:rds:us-west-2:123456789012:db:source",
+			Equivalent: true,
+		},
+		{
+			Old:        "arn:aws:rds:us-west-2:123456789012:db:source",
+			New:        "source",
+			Equivalent: true,
+		},
+		{
+			Old: "source",
+			New: "arn:aws:rds:us-east-1:123456789012:db:source",
+		},
+		{
+			Old: "source",
+			New: "arn:aws:rds:us-west-2:210987654321:db:source",
+		},
+		{
+			Old: "source",
+			New: "arn:aws-us-gov:rds:us-west-2:123456789012:db:source",
+		},
+		{
+			Old: "arn:aws:rds:us-west-2:123456789012:db:source",
+			New: "arn:aws:rds:us-east-1:210987654321:db:source",
+		},
+		{
+			Old: "source",
+			New: "arn:aws:ec2:us-west-2:123456789012:db:source",
+		},
+		{
+			Old: "source",
+			New: "arn:aws:rds:us-west-2:123456789012:cluster:source",
+		},
+		{
+			Old: "other",
+			New: "arn:aws:rds:us-west-2:123456789012:db:source",
+		},
+		{
+			Old: "",
+			New: "arn:aws:rds:us-west-2:123456789012:db:source",
+		},
+		{
+			Old: "source",
+			New: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := rdsSourceDbInstanceIdentifierEquivalent(tc.Old, tc.New, "aws", "us-west-2", "123456789012")
+
+		if got != tc.Equivalent {
+			t.Errorf("rdsSourceDbInstanceIdentifierEquivalent(%q, %q) = %t, expected %t", tc.Old, tc.New, got, tc.Equivalent)
+		}
+	}
+}
+
+func TestNormalizeDbInstanceWindow(t *testing.T) {
+	testCases := []struct {
+		Value    interface{}
+		Expected string
+	}{
+		{
+			Value:    "sun:04:00-sun:05:00",
+			Expected: "sun:04:00-sun:05:00",
+		},
+		{
+			Value:    "Sun:04:00-SUN:05:00",
+			Expected: "sun:04:00-sun:05:00",
+		},
+		{
+			Value:    "04:00-05:00",
+			Expected: "04:00-05:00",
+		},
+		{
+			Value:    "",
+			Expected: "",
+		},
+		{
+			Value:    nil,
+			Expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		got := normalizeDbInstanceWindow(tc.Value)
+
+		if got != tc.Expected {
+			t.Errorf("normalizeDbInstanceWindow(%v) = %q, expected %q", tc.Value, got, tc.Expected)
+		}
+
+		if value, ok := tc.Value.(string); ok && !suppressEquivalentDbInstanceWindow("maintenance_window", got, value, nil) {
+			t.Errorf("suppressEquivalentDbInstanceWindow(%q, %q) = false, expected true", got, value)
+		}
+	}
+
+	if suppressEquivalentDbInstanceWindow("maintenance_window", "sun:04:00-sun:05:00", "mon:04:00-mon:05:00", nil) {
+		t.Errorf("suppressEquivalentDbInstanceWindow() = true for different windows, expected false")
+	}
+}
+
+func TestValidateDbInstanceSqlServerMultiAz(t *testing.T) {
+	testCases := []struct {
+		Engine                string
+		MultiAz               bool
+		BackupRetentionPeriod int
+		ExpectError           bool
+	}{
+		{
+			Engine:                "sqlserver-se",
+			MultiAz:               true,
+			BackupRetentionPeriod: 1,
+		},
+		{
+			Engine:                "sqlserver-ee",
+			MultiAz:               true,
+			BackupRetentionPeriod: 0,
+			ExpectError:           true,
+		},
+		{
+			Engine:                "sqlserver-se",
+			MultiAz:               false,
+			BackupRetentionPeriod: 0,
+		},
+		{
+			Engine:                "mysql",
+			MultiAz:               true,
+			BackupRetentionPeriod: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceSqlServerMultiAz(tc.Engine, tc.MultiAz, tc.BackupRetentionPeriod)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for engine %q with multi_az %t and backup_retention_period %d", tc.Engine, tc.MultiAz, tc.BackupRetentionPeriod)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for engine %q with multi_az %t and backup_retention_period %d: %s", tc.Engine, tc.MultiAz, tc.BackupRetentionPeriod, err)
+		}
+	}
+}
+
+func TestDbInstanceMaxAllocatedStorageDisabledEquivalent(t *testing.T) {
+	testCases := []struct {
+		Old              string
+		New              string
+		AllocatedStorage int
+		Equivalent       bool
+	}{
+		{
+			Old:              "0",
+			New:              "100",
+			AllocatedStorage: 100,
+			Equivalent:       true,
+		},
+		{
+			Old:              "0",
+			New:              "200",
+			AllocatedStorage: 100,
+		},
+		{
+			Old:              "200",
+			New:              "100",
+			AllocatedStorage: 100,
+		},
+		{
+			Old:              "",
+			New:              "100",
+			AllocatedStorage: 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		got := dbInstanceMaxAllocatedStorageDisabledEquivalent(tc.Old, tc.New, tc.AllocatedStorage)
+
+		if got != tc.Equivalent {
+			t.Errorf("dbInstanceMaxAllocatedStorageDisabledEquivalent(%q, %q, %d) = %t, expected %t", tc.Old, tc.New, tc.AllocatedStorage, got, tc.Equivalent)
+		}
+	}
+}
+
+func TestValidateDbInstanceEngine(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "mysql",
+			ErrCount: 0,
+		},
+		{
+			Value:    "postgres",
+			ErrCount: 0,
+		},
+		{
+			Value:    "",
+			ErrCount: 0,
+		},
+		{
+			Value:    "aurora",
+			ErrCount: 1,
+		},
+		{
+			Value:    "aurora-mysql",
+			ErrCount: 1,
+		},
+		{
+			Value:    "Aurora-PostgreSQL",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		_, errors := validateDbInstanceEngine(tc.Value, "engine")
+
+		if len(errors) != tc.ErrCount {
+			t.Errorf("expected %d validation errors for %q, got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestValidateDbInstanceStorage(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Engine           string
+		StorageType      string
+		AllocatedStorage int
+		Iops             int
+		Inherited        bool
+		ExpectError      bool
+	}{
+		{
+			Name: "unset",
+		},
+		{
+			Name:             "standard",
+			Engine:           "mysql",
+			StorageType:      "standard",
+			AllocatedStorage: 20,
+		},
+		{
+			Name:             "standard with iops",
+			Engine:           "mysql",
+			StorageType:      "standard",
+			AllocatedStorage: 200,
+			Iops:             1000,
+			ExpectError:      true,
+		},
+		{
+			Name:             "gp2",
+			Engine:           "mysql",
+			StorageType:      "gp2",
+			AllocatedStorage: 20,
+		},
+		{
+			Name:             "gp2 with iops",
+			Engine:           "mysql",
+			StorageType:      "gp2",
+			AllocatedStorage: 200,
+			Iops:             1000,
+			ExpectError:      true,
+		},
+		{
+			Name:             "io1",
+			Engine:           "mysql",
+			StorageType:      "io1",
+			AllocatedStorage: 200,
+			Iops:             1000,
+		},
+		{
+			Name:             "io1 without iops",
+			Engine:           "mysql",
+			StorageType:      "io1",
+			AllocatedStorage: 200,
+			ExpectError:      true,
+		},
+		{
+			Name:        "io1 with inherited iops",
+			StorageType: "io1",
+			Inherited:   true,
+		},
+		{
+			Name:             "io1 above ratio",
+			Engine:           "mysql",
+			StorageType:      "io1",
+			AllocatedStorage: 200,
+			Iops:             10001,
+			ExpectError:      true,
+		},
+		{
+			Name:             "io1 below ratio",
+			Engine:           "mysql",
+			StorageType:      "io1",
+			AllocatedStorage: 2001,
+			Iops:             1000,
+			ExpectError:      true,
+		},
+		{
+			Name:             "io1 below sqlserver ratio",
+			Engine:           "sqlserver-se",
+			StorageType:      "io1",
+			AllocatedStorage: 1001,
+			Iops:             1000,
+			ExpectError:      true,
+		},
+		{
+			Name:             "unset storage type with iops",
+			Engine:           "mysql",
+			AllocatedStorage: 200,
+			Iops:             1000,
+		},
+		{
+			Name:             "unset storage type above ratio",
+			Engine:           "mysql",
+			AllocatedStorage: 200,
+			Iops:             10001,
+			ExpectError:      true,
+		},
+		{
+			Name:             "unsupported storage type",
+			Engine:           "mysql",
+			StorageType:      "gp1",
+			AllocatedStorage: 20,
+			ExpectError:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstanceStorage(tc.Engine, tc.StorageType, tc.AllocatedStorage, tc.Iops, tc.Inherited)
+
+			if tc.ExpectError && err == nil {
+				t.Errorf("expected error")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstanceIopsStorageType(t *testing.T) {
+	testCases := []struct {
+		StorageType string
+		Iops        int
+		ExpectError bool
+	}{
+		{
+			StorageType: "io1",
+			Iops:        1000,
+		},
+		{
+			StorageType: "gp2",
+		},
+		{
+			StorageType: "",
+			Iops:        1000,
+		},
+		{
+			StorageType: "gp2",
+			Iops:        1000,
+			ExpectError: true,
+		},
+		{
+			StorageType: "standard",
+			Iops:        1000,
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceIopsStorageType(tc.StorageType, tc.Iops)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for storage type %q with iops %d", tc.StorageType, tc.Iops)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for storage type %q with iops %d: %s", tc.StorageType, tc.Iops, err)
+		}
+	}
+}
+
+func TestValidateDbInstanceIopsRatio(t *testing.T) {
+	testCases := []struct {
+		Engine           string
+		AllocatedStorage int
+		Iops             int
+		ExpectError      bool
+	}{
+		{
+			Engine:           "mysql",
+			AllocatedStorage: 200,
+			Iops:             1000,
+		},
+		{
+			Engine:           "mysql",
+			AllocatedStorage: 200,
+			Iops:             10000,
+		},
+		{
+			Engine:           "mysql",
+			AllocatedStorage: 200,
+			Iops:             10001,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "mysql",
+			AllocatedStorage: 2000,
+			Iops:             1000,
+		},
+		{
+			Engine:           "mysql",
+			AllocatedStorage: 2001,
+			Iops:             1000,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "sqlserver-se",
+			AllocatedStorage: 1000,
+			Iops:             1000,
+		},
+		{
+			Engine:           "sqlserver-se",
+			AllocatedStorage: 1001,
+			Iops:             1000,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "",
+			AllocatedStorage: 0,
+			Iops:             1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceIopsRatio(tc.Engine, tc.AllocatedStorage, tc.Iops)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for engine %q with allocated_storage %d and iops %d", tc.Engine, tc.AllocatedStorage, tc.Iops)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for engine %q with allocated_storage %d and iops %d: %s", tc.Engine, tc.AllocatedStorage, tc.Iops, err)
+		}
+	}
+}
+
+func TestValidateDbInstancePort(t *testing.T) {
+	testCases := []struct {
+		Engine      string
+		Port        int
+		ExpectError bool
+	}{
+		{
+			Engine: "mysql",
+			Port:   3306,
+		},
+		{
+			Engine: "mysql",
+			Port:   3389,
+		},
+		{
+			Engine: "sqlserver-ex",
+			Port:   1433,
+		},
+		{
+			Engine: "postgres",
+			Port:   65535,
+		},
+		{
+			Engine:      "postgres",
+			Port:        1149,
+			ExpectError: true,
+		},
+		{
+			Engine:      "",
+			Port:        65536,
+			ExpectError: true,
+		},
+		{
+			Engine: "sqlserver-ex",
+		},
+		{
+			Engine:      "sqlserver-ex",
+			Port:        1434,
+			ExpectError: true,
+		},
+		{
+			Engine:      "sqlserver-se",
+			Port:        3389,
+			ExpectError: true,
+		},
+		{
+			Engine:      "sqlserver-ee",
+			Port:        49154,
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstancePort(tc.Engine, tc.Port)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for engine %q with port %d", tc.Engine, tc.Port)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for engine %q with port %d: %s", tc.Engine, tc.Port, err)
+		}
+	}
+}
+
+func TestValidateDbInstanceAllocatedStorage(t *testing.T) {
+	testCases := []struct {
+		Engine           string
+		AllocatedStorage int
+		ExpectError      bool
+	}{
+		{
+			Engine: "mysql",
+		},
+		{
+			Engine:           "mysql",
+			AllocatedStorage: 5,
+		},
+		{
+			Engine:           "mysql",
+			AllocatedStorage: 4,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "mariadb",
+			AllocatedStorage: 4,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "postgres",
+			AllocatedStorage: 5,
+		},
+		{
+			Engine:           "oracle-se2",
+			AllocatedStorage: 10,
+		},
+		{
+			Engine:           "oracle-ee",
+			AllocatedStorage: 5,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "sqlserver-ex",
+			AllocatedStorage: 20,
+		},
+		{
+			Engine:           "sqlserver-ex",
+			AllocatedStorage: 10,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "sqlserver-se",
+			AllocatedStorage: 19,
+			ExpectError:      true,
+		},
+		{
+			Engine:           "",
+			AllocatedStorage: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceAllocatedStorage(tc.Engine, tc.AllocatedStorage)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for engine %q with allocated storage %d", tc.Engine, tc.AllocatedStorage)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for engine %q with allocated storage %d: %s", tc.Engine, tc.AllocatedStorage, err)
+		}
+	}
+}
+
+func TestValidateDbInstanceUsername(t *testing.T) {
+	testCases := []struct {
+		Engine      string
+		Username    string
+		ExpectError bool
+	}{
+		{
+			Engine:   "mysql",
+			Username: "tfacctest",
+		},
+		{
+			Engine:      "mysql",
+			Username:    "rdsadmin",
+			ExpectError: true,
+		},
+		{
+			Engine:   "mysql",
+			Username: "postgres",
+		},
+		{
+			Engine:   "mariadb",
+			Username: "tfacctest",
+		},
+		{
+			Engine:      "mariadb",
+			Username:    "RDSAdmin",
+			ExpectError: true,
+		},
+		{
+			Engine:   "postgres",
+			Username: "tfacctest",
+		},
+		{
+			Engine:      "postgres",
+			Username:    "rdsadmin",
+			ExpectError: true,
+		},
+		{
+			Engine:   "postgres",
+			Username: "postgres",
+		},
+		{
+			Engine:   "oracle-se2",
+			Username: "tfacctest",
+		},
+		{
+			Engine:      "oracle-ee",
+			Username:    "system",
+			ExpectError: true,
+		},
+		{
+			Engine:   "sqlserver-ex",
+			Username: "tfacctest",
+		},
+		{
+			Engine:      "sqlserver-se",
+			Username:    "sa",
+			ExpectError: true,
+		},
+		{
+			Engine:      "",
+			Username:    "rdsadmin",
+			ExpectError: true,
+		},
+		{
+			Engine: "postgres",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceUsername(tc.Engine, tc.Username)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for engine %q with username %q", tc.Engine, tc.Username)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for engine %q with username %q: %s", tc.Engine, tc.Username, err)
+		}
+	}
+}
+
+func TestValidateDbInstanceReplicaMultiAz(t *testing.T) {
+	testCases := []struct {
+		Engine      string
+		MultiAz     bool
+		ExpectError bool
+	}{
+		{
+			Engine:  "mysql",
+			MultiAz: true,
+		},
+		{
+			Engine:  "postgres",
+			MultiAz: true,
+		},
+		{
+			Engine:  "oracle-ee",
+			MultiAz: true,
+		},
+		{
+			Engine:  "",
+			MultiAz: true,
+		},
+		{
+			Engine:      "sqlserver-ee",
+			MultiAz:     true,
+			ExpectError: true,
+		},
+		{
+			Engine: "sqlserver-ee",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceReplicaMultiAz(tc.Engine, tc.MultiAz)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for replica engine %q with multi_az %t", tc.Engine, tc.MultiAz)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for replica engine %q with multi_az %t: %s", tc.Engine, tc.MultiAz, err)
+		}
+	}
+}
+
+func TestValidateDbInstanceAvailabilityZoneInSubnetGroup(t *testing.T) {
+	dbSubnetGroup := &rds.DBSubnetGroup{
+		DBSubnetGroupName: aws.String("tf-acc-test"),
+		Subnets: []*rds.Subnet{
+			{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2b")}},
+			nil,
+			{SubnetAvailabilityZone: &rds.AvailabilityZone{Name: aws.String("us-west-2a")}},
+		},
+	}
+
+	testCases := []struct {
+		Name             string
+		AvailabilityZone string
+		DBSubnetGroup    *rds.DBSubnetGroup
+		ExpectedError    string
+	}{
+		{
+			Name:             "covered",
+			AvailabilityZone: "us-west-2a",
+			DBSubnetGroup:    dbSubnetGroup,
+		},
+		{
+			Name:             "not covered",
+			AvailabilityZone: "us-west-2c",
+			DBSubnetGroup:    dbSubnetGroup,
+			ExpectedError:    "availability_zone (us-west-2c) has no subnet in db_subnet_group_name (tf-acc-test), which covers: us-west-2a, us-west-2b",
+		},
+		{
+			Name:             "no subnets",
+			AvailabilityZone: "us-west-2c",
+			DBSubnetGroup:    &rds.DBSubnetGroup{DBSubnetGroupName: aws.String("tf-acc-test")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstanceAvailabilityZoneInSubnetGroup(tc.AvailabilityZone, tc.DBSubnetGroup)
+
+			if tc.ExpectedError == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tc.ExpectedError != "" && (err == nil || err.Error() != tc.ExpectedError) {
+				t.Fatalf("expected error %q, got: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstanceCACertificateIdentifierAvailable(t *testing.T) {
+	certificates := []*rds.Certificate{
+		{CertificateIdentifier: aws.String("rds-ca-2019")},
+		nil,
+		{CertificateIdentifier: aws.String("rds-ca-2015")},
+	}
+
+	testCases := []struct {
+		Name          string
+		Identifier    string
+		Certificates  []*rds.Certificate
+		ExpectedError string
+	}{
+		{
+			Name:         "available",
+			Identifier:   "rds-ca-2019",
+			Certificates: certificates,
+		},
+		{
+			Name:          "not available",
+			Identifier:    "rds-ca-bogus",
+			Certificates:  certificates,
+			ExpectedError: "ca_cert_identifier (rds-ca-bogus) is not available in region (us-west-2); valid identifiers are: rds-ca-2015, rds-ca-2019",
+		},
+		{
+			Name:       "no certificates",
+			Identifier: "rds-ca-bogus",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstanceCACertificateIdentifierAvailable("us-west-2", tc.Identifier, tc.Certificates)
+
+			if tc.ExpectedError == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tc.ExpectedError != "" && (err == nil || err.Error() != tc.ExpectedError) {
+				t.Fatalf("expected error %q, got: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstanceMonitoringRoleArnRequired(t *testing.T) {
+	monitoringRoleArn := "arn:aws:iam::123456789012:role/rds-monitoring-role"
+
+	testCases := []struct {
+		MonitoringInterval   int
+		MonitoringRoleArn    string
+		CreateMonitoringRole bool
+		ExpectError          bool
+	}{
+		{
+			MonitoringInterval: 0,
+		},
+		{
+			MonitoringInterval: 30,
+			MonitoringRoleArn:  monitoringRoleArn,
+		},
+		{
+			MonitoringInterval:   30,
+			CreateMonitoringRole: true,
+		},
+		{
+			MonitoringInterval: 30,
+			ExpectError:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceMonitoringRoleArnRequired(tc.MonitoringInterval, tc.MonitoringRoleArn, tc.CreateMonitoringRole)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for monitoring_interval %d with monitoring_role_arn %q and create_monitoring_role %t", tc.MonitoringInterval, tc.MonitoringRoleArn, tc.CreateMonitoringRole)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for monitoring_interval %d with monitoring_role_arn %q and create_monitoring_role %t: %s", tc.MonitoringInterval, tc.MonitoringRoleArn, tc.CreateMonitoringRole, err)
+		}
+	}
+}
+
+func TestValidateDbInstancePerformanceInsightsKmsKeyId(t *testing.T) {
+	kmsKeyId := "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	testCases := []struct {
+		Enabled     bool
+		KmsKeyId    string
+		ExpectError bool
+	}{
+		{
+			Enabled:  true,
+			KmsKeyId: kmsKeyId,
+		},
+		{
+			Enabled: true,
+		},
+		{
+			Enabled: false,
+		},
+		{
+			Enabled:     false,
+			KmsKeyId:    kmsKeyId,
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstancePerformanceInsightsKmsKeyId(tc.Enabled, tc.KmsKeyId)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for performance_insights_enabled %t with performance_insights_kms_key_id %q", tc.Enabled, tc.KmsKeyId)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for performance_insights_enabled %t with performance_insights_kms_key_id %q: %s", tc.Enabled, tc.KmsKeyId, err)
+		}
+	}
+}
+
+func TestValidateDbInstanceReplicaBackupRetentionPeriod(t *testing.T) {
+	testCases := []struct {
+		Engine                string
+		BackupRetentionPeriod int
+		ExpectError           bool
+	}{
+		{
+			Engine:                "mysql",
+			BackupRetentionPeriod: 1,
+		},
+		{
+			Engine:                "mariadb",
+			BackupRetentionPeriod: 7,
+		},
+		{
+			Engine:                "",
+			BackupRetentionPeriod: 1,
+		},
+		{
+			Engine:                "postgres",
+			BackupRetentionPeriod: 1,
+			ExpectError:           true,
+		},
+		{
+			Engine: "postgres",
+		},
+		{
+			Engine:                "sqlserver-ee",
+			BackupRetentionPeriod: 1,
+			ExpectError:           true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := validateDbInstanceReplicaBackupRetentionPeriod(tc.Engine, tc.BackupRetentionPeriod)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for replica engine %q with backup_retention_period %d", tc.Engine, tc.BackupRetentionPeriod)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for replica engine %q with backup_retention_period %d: %s", tc.Engine, tc.BackupRetentionPeriod, err)
+		}
+	}
+}
+
+func TestIamRoleNameFromArn(t *testing.T) {
+	testCases := []struct {
+		Arn         string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Arn:      "arn:aws:iam::123456789012:role/tf-acc-test",
+			Expected: "tf-acc-test",
+		},
+		{
+			Arn:      "arn:aws-us-gov:iam::123456789012:role/service-role/tf-acc-test",
+			Expected: "tf-acc-test",
+		},
+		{
+			Arn:         "arn:aws:iam::123456789012:user/tf-acc-test",
+			ExpectError: true,
+		},
+		{
+			Arn:         "arn:aws:s3:::tf-acc-test",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		parsedArn, err := arn.Parse(tc.Arn)
+
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %s", tc.Arn, err)
+		}
+
+		got, err := iamRoleNameFromArn(parsedArn)
+
+		if tc.ExpectError && err == nil {
+			t.Errorf("expected error for %s", tc.Arn)
+		}
+
+		if !tc.ExpectError && err != nil {
+			t.Errorf("unexpected error for %s: %s", tc.Arn, err)
+		}
+
+		if got != tc.Expected {
+			t.Errorf("iamRoleNameFromArn(%s) = %q, expected %q", tc.Arn, got, tc.Expected)
+		}
+	}
+}
+
+func TestIamRolePolicyTrustsService(t *testing.T) {
+	testCases := []struct {
+		Document string
+		Expected bool
+	}{
+		{
+			Document: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"directoryservice.rds.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			Expected: true,
+		},
+		{
+			Document: `%7B%22Version%22%3A%222012-10-17%22%2C%22Statement%22%3A%5B%7B%22Effect%22%3A%22Allow%22%2C%22Principal%22%3A%7B%22Service%22%3A%5B%22rds.amazonaws.com%22%2C%22directoryservice.rds.amazonaws.com%22%5D%7D%2C%22Action%22%3A%22sts%3AAssumeRole%22%7D%5D%7D`,
+			Expected: true,
+		},
+		{
+			Document: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"rds.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+			Expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := iamRolePolicyTrustsService(tc.Document, "directoryservice.rds.amazonaws.com")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got != tc.Expected {
+			t.Errorf("iamRolePolicyTrustsService(%s) = %t, expected %t", tc.Document, got, tc.Expected)
+		}
+	}
+}
+
+func TestDiffCloudwatchLogsExportConfiguration(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		Old             []interface{}
+		New             []interface{}
+		ExpectedEnable  []interface{}
+		ExpectedDisable []interface{}
+	}{
+		{
+			Name:            "enable from empty",
+			Old:             []interface{}{},
+			New:             []interface{}{"audit", "error"},
+			ExpectedEnable:  []interface{}{"audit", "error"},
+			ExpectedDisable: []interface{}{},
+		},
+		{
+			Name:            "add",
+			Old:             []interface{}{"audit", "error"},
+			New:             []interface{}{"audit", "error", "general"},
+			ExpectedEnable:  []interface{}{"general"},
+			ExpectedDisable: []interface{}{},
+		},
+		{
+			Name:            "modify",
+			Old:             []interface{}{"audit", "error", "general"},
+			New:             []interface{}{"audit", "general", "slowquery"},
+			ExpectedEnable:  []interface{}{"slowquery"},
+			ExpectedDisable: []interface{}{"error"},
+		},
+		{
+			Name:            "remove all",
+			Old:             []interface{}{"audit", "general", "slowquery"},
+			New:             []interface{}{},
+			ExpectedEnable:  []interface{}{},
+			ExpectedDisable: []interface{}{"audit", "general", "slowquery"},
+		},
+		{
+			Name:            "unchanged",
+			Old:             []interface{}{"audit"},
+			New:             []interface{}{"audit"},
+			ExpectedEnable:  []interface{}{},
+			ExpectedDisable: []interface{}{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			enable, disable := diffCloudwatchLogsExportConfiguration(tc.Old, tc.New)
+
+			if !reflect.DeepEqual(enable, tc.ExpectedEnable) {
+				t.Errorf("expected enable %v, got %v", tc.ExpectedEnable, enable)
+			}
+
+			if !reflect.DeepEqual(disable, tc.ExpectedDisable) {
+				t.Errorf("expected disable %v, got %v", tc.ExpectedDisable, disable)
+			}
+		})
+	}
+}
+
+func TestFlattenDbInstancePendingModifiedValues(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    *rds.PendingModifiedValues
+		Expected int
+	}{
+		{
+			Name:     "nil",
+			Expected: 0,
+		},
+		{
+			Name:     "nothing pending",
+			Input:    &rds.PendingModifiedValues{},
+			Expected: 0,
+		},
+		{
+			Name: "instance class pending",
+			Input: &rds.PendingModifiedValues{
+				DBInstanceClass: aws.String("db.t3.micro"),
+			},
+			Expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := flattenDbInstancePendingModifiedValues(tc.Input)
+
+			if len(got) != tc.Expected {
+				t.Fatalf("expected %d elements, got %d", tc.Expected, len(got))
+			}
+
+			if tc.Expected == 0 {
+				return
+			}
+
+			if v := got[0].(map[string]interface{})["instance_class"]; v != aws.StringValue(tc.Input.DBInstanceClass) {
+				t.Errorf("expected instance_class %q, got %q", aws.StringValue(tc.Input.DBInstanceClass), v)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstanceSnapshotRestoreKmsKey(t *testing.T) {
+	keyARN := "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	testCases := []struct {
+		Name        string
+		Snapshot    *rds.DBSnapshot
+		KmsKeyID    string
+		ExpectError bool
+	}{
+		{
+			Name: "unencrypted",
+			Snapshot: &rds.DBSnapshot{
+				DBSnapshotIdentifier: aws.String("test"),
+				Encrypted:            aws.Bool(false),
+			},
+			KmsKeyID:    keyARN,
+			ExpectError: true,
+		},
+		{
+			Name: "different key",
+			Snapshot: &rds.DBSnapshot{
+				DBSnapshotIdentifier: aws.String("test"),
+				Encrypted:            aws.Bool(true),
+				KmsKeyId:             aws.String("arn:aws:kms:us-west-2:123456789012:key/ffffffff-12ab-34cd-56ef-1234567890ab"),
+			},
+			KmsKeyID:    keyARN,
+			ExpectError: true,
+		},
+		{
+			Name: "same key",
+			Snapshot: &rds.DBSnapshot{
+				DBSnapshotIdentifier: aws.String("test"),
+				Encrypted:            aws.Bool(true),
+				KmsKeyId:             aws.String(keyARN),
+			},
+			KmsKeyID: keyARN,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstanceSnapshotRestoreKmsKey(tc.Snapshot, tc.KmsKeyID)
+
+			if tc.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstanceEngineVersionUpgrade(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Old         string
+		New         string
+		ExpectError bool
+	}{
+		{
+			Name: "unchanged",
+			Old:  "5.7.22",
+			New:  "5.7.22",
+		},
+		{
+			Name: "patch upgrade",
+			Old:  "5.7.22",
+			New:  "5.7.31",
+		},
+		{
+			Name: "minor upgrade",
+			Old:  "11.8",
+			New:  "11.9",
+		},
+		{
+			Name: "major upgrade",
+			Old:  "5.7.31",
+			New:  "8.0.21",
+		},
+		{
+			Name: "prefix of current version",
+			Old:  "5.7.22",
+			New:  "5.7",
+		},
+		{
+			Name:        "patch downgrade",
+			Old:         "5.7.31",
+			New:         "5.7.22",
+			ExpectError: true,
+		},
+		{
+			Name:        "minor downgrade",
+			Old:         "11.9",
+			New:         "11.8",
+			ExpectError: true,
+		},
+		{
+			Name:        "major downgrade",
+			Old:         "8.0.21",
+			New:         "5.7.31",
+			ExpectError: true,
+		},
+		{
+			Name:        "major downgrade to prefix",
+			Old:         "5.7.22",
+			New:         "5.6",
+			ExpectError: true,
+		},
+		{
+			Name:        "not a prefix of current version",
+			Old:         "10.11",
+			New:         "10.1",
+			ExpectError: true,
+		},
+		{
+			Name: "unparsable version",
+			Old:  "12.1.0.2.v21",
+			New:  "12.1.0.2.v20",
+		},
+		{
+			Name: "new instance",
+			Old:  "",
+			New:  "5.7.22",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstanceEngineVersionUpgrade(tc.Old, tc.New)
+
+			if tc.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstanceSecurityGroupNamesPlatforms(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Platforms   []string
+		ExpectError bool
+	}{
+		{
+			Name: "unknown platforms",
+		},
+		{
+			Name:      "EC2-Classic and VPC",
+			Platforms: []string{"EC2", "VPC"},
+		},
+		{
+			Name:        "VPC only",
+			Platforms:   []string{"VPC"},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstanceSecurityGroupNamesPlatforms(tc.Platforms)
+
+			if tc.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestDbInstanceCurrentOptionGroupMembership(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Memberships []*rds.OptionGroupMembership
+		Expected    string
+	}{
+		{
+			Name: "no memberships",
+		},
+		{
+			Name: "default option group",
+			Memberships: []*rds.OptionGroupMembership{
+				{OptionGroupName: aws.String("default:postgres-11"), Status: aws.String("in-sync")},
+			},
+			Expected: "default:postgres-11",
+		},
+		{
+			Name: "previous option group listed first",
+			Memberships: []*rds.OptionGroupMembership{
+				{OptionGroupName: aws.String("default:mysql-5-7"), Status: aws.String("removing")},
+				{OptionGroupName: aws.String("tf-acc-test"), Status: aws.String("applying")},
+			},
+			Expected: "tf-acc-test",
+		},
+		{
+			Name: "only previous option group",
+			Memberships: []*rds.OptionGroupMembership{
+				{OptionGroupName: aws.String("default:mysql-5-7"), Status: aws.String("pending-removal")},
+			},
+			Expected: "default:mysql-5-7",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var got string
+			if membership := dbInstanceCurrentOptionGroupMembership(tc.Memberships); membership != nil {
+				got = aws.StringValue(membership.OptionGroupName)
+			}
+
+			if got != tc.Expected {
+				t.Errorf("got %q, expected %q", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestDbInstanceEngineVersionHasMajorVersion(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		EngineVersion string
+		MajorVersion  string
+		Expected      bool
+	}{
+		{
+			Name:          "mysql patch version",
+			EngineVersion: "5.7.22",
+			MajorVersion:  "5.7",
+			Expected:      true,
+		},
+		{
+			Name:          "postgres minor version",
+			EngineVersion: "12.3",
+			MajorVersion:  "12",
+			Expected:      true,
+		},
+		{
+			Name:          "major version only",
+			EngineVersion: "5.7",
+			MajorVersion:  "5.7",
+			Expected:      true,
+		},
+		{
+			Name:          "sqlserver version",
+			EngineVersion: "14.00.3281.6.v1",
+			MajorVersion:  "14.00",
+			Expected:      true,
+		},
+		{
+			Name:          "different major version",
+			EngineVersion: "5.6.34",
+			MajorVersion:  "5.7",
+		},
+		{
+			Name:          "numeric prefix",
+			EngineVersion: "11.8",
+			MajorVersion:  "1",
+		},
+		{
+			Name:          "empty major version",
+			EngineVersion: "5.7.22",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := dbInstanceEngineVersionHasMajorVersion(tc.EngineVersion, tc.MajorVersion); got != tc.Expected {
+				t.Errorf("got %t, expected %t", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstanceOptionGroupMajorEngineVersion(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		MajorEngineVersion string
+		EngineVersion      string
+		ExpectError        bool
+	}{
+		{
+			Name:               "same major version",
+			MajorEngineVersion: "5.7",
+			EngineVersion:      "5.7.22",
+		},
+		{
+			Name:               "major version only",
+			MajorEngineVersion: "5.7",
+			EngineVersion:      "5.7",
+		},
+		{
+			Name:               "new major version",
+			MajorEngineVersion: "5.7",
+			EngineVersion:      "8.0.21",
+			ExpectError:        true,
+		},
+		{
+			Name:               "postgres new major version",
+			MajorEngineVersion: "11",
+			EngineVersion:      "12.3",
+			ExpectError:        true,
+		},
+		{
+			Name:          "unknown major version",
+			EngineVersion: "8.0.21",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstanceOptionGroupMajorEngineVersion("tf-acc-test", tc.MajorEngineVersion, tc.EngineVersion)
+
+			if tc.ExpectError && err == nil {
+				t.Fatalf("expected error")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateDbInstancePerformanceInsights(t *testing.T) {
+	instanceOptions := []*rds.OrderableDBInstanceOption{
+		{Engine: aws.String("mysql"), EngineVersion: aws.String("5.6.41"), DBInstanceClass: aws.String("db.m3.medium"), SupportsPerformanceInsights: aws.Bool(true)},
+		{Engine: aws.String("mysql"), EngineVersion: aws.String("5.6.41"), DBInstanceClass: aws.String("db.t2.micro"), SupportsPerformanceInsights: aws.Bool(false)},
+		{Engine: aws.String("mysql"), EngineVersion: aws.String("5.7.22"), DBInstanceClass: aws.String("db.m3.medium"), SupportsPerformanceInsights: aws.Bool(false)},
+		nil,
+	}
+
+	testCases := []struct {
+		Name          string
+		Engine        string
+		EngineVersion string
+		InstanceClass string
+		ExpectError   bool
+	}{
+		{
+			Name:          "supported class",
+			Engine:        "mysql",
+			EngineVersion: "5.6.41",
+			InstanceClass: "db.m3.medium",
+		},
+		{
+			Name:          "unsupported class",
+			Engine:        "mysql",
+			EngineVersion: "5.6.41",
+			InstanceClass: "db.t2.micro",
+			ExpectError:   true,
+		},
+		{
+			Name:          "unsupported version",
+			Engine:        "mysql",
+			EngineVersion: "5.7.22",
+			InstanceClass: "db.m3.medium",
+			ExpectError:   true,
+		},
+		{
+			Name:          "version prefix",
+			Engine:        "mysql",
+			EngineVersion: "5.6",
+			InstanceClass: "db.m3.medium",
+		},
+		{
+			Name:          "any version supported",
+			Engine:        "MySQL",
+			InstanceClass: "db.m3.medium",
+		},
+		{
+			Name:          "unknown class",
+			Engine:        "mysql",
+			InstanceClass: "db.m5.large",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateDbInstancePerformanceInsights(instanceOptions, tc.Engine, tc.EngineVersion, tc.InstanceClass)
+
+			if tc.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestIsDbInstanceOptionGroupPropagationError(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Err      error
+		Expected bool
+	}{
+		{
+			Name: "nil",
+		},
+		{
+			Name: "other error",
+			Err:  errors.New("The option group tf-acc-test is not available"),
+		},
+		{
+			Name: "other code",
+			Err:  awserr.New(rds.ErrCodeOptionGroupNotFoundFault, "The option group tf-acc-test was not found", nil),
+		},
+		{
+			Name: "other InvalidParameterCombination",
+			Err:  awserr.New("InvalidParameterCombination", "RDS does not support creating a DB instance with the following combination", nil),
+		},
+		{
+			Name:     "option group",
+			Err:      awserr.New("InvalidParameterCombination", "The Option Group tf-acc-test is not available", nil),
+			Expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := isDbInstanceOptionGroupPropagationError(tc.Err); got != tc.Expected {
+				t.Errorf("got %t, expected %t", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestRetryDbInstanceOptionGroupPropagation(t *testing.T) {
+	propagationErr := awserr.New("InvalidParameterCombination", "The option group tf-acc-test is not available", nil)
+
+	testCases := []struct {
+		Name            string
+		OptionGroupName string
+		Errs            []error
+		ExpectedCalls   int
+		ExpectError     bool
+	}{
+		{
+			Name:            "propagation error once",
+			OptionGroupName: "tf-acc-test",
+			Errs:            []error{propagationErr, nil},
+			ExpectedCalls:   2,
+		},
+		{
+			Name:          "no option group",
+			Errs:          []error{propagationErr, nil},
+			ExpectedCalls: 1,
+			ExpectError:   true,
+		},
+		{
+			Name:            "other error",
+			OptionGroupName: "tf-acc-test",
+			Errs:            []error{awserr.New("InvalidParameterValue", "Invalid DB instance class", nil), nil},
+			ExpectedCalls:   1,
+			ExpectError:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var calls int
+
+			err := retryDbInstanceOptionGroupPropagation(tc.OptionGroupName, func() error {
+				err := tc.Errs[calls]
+				calls++
+				return err
+			})
+
+			if tc.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !tc.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if calls != tc.ExpectedCalls {
+				t.Errorf("got %d calls, expected %d", calls, tc.ExpectedCalls)
+			}
+		})
+	}
+}
+
+func TestExpandDbInstanceRestoreToPointInTime(t *testing.T) {
+	testCases := []struct {
+		Name                string
+		Input               map[string]interface{}
+		ExpectError         bool
+		ExpectedRestoreTime string
+	}{
+		{
+			Name: "latest restorable time",
+			Input: map[string]interface{}{
+				"source_db_instance_identifier": "source",
+				"use_latest_restorable_time":    true,
+			},
+		},
+		{
+			Name: "restore time",
+			Input: map[string]interface{}{
+				"source_db_instance_identifier": "source",
+				"restore_time":                  "2020-10-01T12:30:00Z",
+			},
+			ExpectedRestoreTime: "2020-10-01T12:30:00Z",
+		},
+		{
+			Name: "no point in time",
+			Input: map[string]interface{}{
+				"source_db_instance_identifier": "source",
+				"use_latest_restorable_time":    false,
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			input := &rds.RestoreDBInstanceToPointInTimeInput{}
+			err := expandDbInstanceRestoreToPointInTime(tc.Input, input)
+
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tc.ExpectedRestoreTime != "" {
+				if got := aws.TimeValue(input.RestoreTime).Format(time.RFC3339); got != tc.ExpectedRestoreTime {
+					t.Errorf("expected restore time %s, got %s", tc.ExpectedRestoreTime, got)
+				}
+			}
+		})
+	}
+}
+
 func TestAccAWSDBInstance_basic(t *testing.T) {
 	var dbInstance1 rds.DBInstance
-	resourceName := "aws_db_instance.bar"
+	resourceName := "aws_db_instance.bar"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					testAccCheckAWSDBInstanceAttributes(&dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "10"),
+					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "false"),
+					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "true"),
+					testAccMatchResourceAttrRegionalARN(resourceName, "arn", "rds", regexp.MustCompile(`db:.+`)),
+					resource.TestCheckResourceAttrSet(resourceName, "availability_zone"),
+					resource.TestCheckResourceAttr(resourceName, "backup_retention_period", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "backup_window"),
+					resource.TestCheckResourceAttrSet(resourceName, "ca_cert_identifier"),
+					resource.TestCheckResourceAttr(resourceName, "certificate_details.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "certificate_details.0.ca_identifier", resourceName, "ca_cert_identifier"),
+					resource.TestCheckResourceAttrSet(resourceName, "certificate_details.0.valid_till"),
+					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "false"),
+					resource.TestCheckResourceAttr(resourceName, "db_subnet_group_name", "default"),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enabled_cloudwatch_logs_exports.#", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "endpoint"),
+					resource.TestCheckResourceAttr(resourceName, "engine", "mysql"),
+					resource.TestCheckResourceAttrSet(resourceName, "engine_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "hosted_zone_id"),
+					resource.TestCheckResourceAttr(resourceName, "iam_database_authentication_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "instance_class", "db.t2.micro"),
+					resource.TestCheckResourceAttr(resourceName, "license_model", "general-public-license"),
+					resource.TestCheckResourceAttrSet(resourceName, "maintenance_window"),
+					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "0"),
+					resource.TestCheckResourceAttr(resourceName, "name", "baz"),
+					resource.TestCheckResourceAttr(resourceName, "option_group_name", "default:mysql-5-6"),
+					resource.TestCheckResourceAttr(resourceName, "parameter_group_name", "default.mysql5.6"),
+					resource.TestCheckResourceAttr(resourceName, "port", "3306"),
+					resource.TestCheckResourceAttr(resourceName, "publicly_accessible", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "resource_id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "available"),
+					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "false"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "username", "foo"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"final_snapshot_identifier",
+					"password",
+					"skip_final_snapshot",
+					"delete_automated_backups",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_namePrefix(t *testing.T) {
+	var v rds.DBInstance
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_namePrefix,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.test", &v),
+					testAccCheckAWSDBInstanceAttributes(&v),
+					resource.TestMatchResourceAttr(
+						"aws_db_instance.test", "identifier", regexp.MustCompile("^tf-test-")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_Identifier_Rename(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+	resourceName := "aws_db_instance.test"
+	rName1 := acctest.RandomWithPrefix("tf-acc-test")
+	rName2 := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_MariaDB(rName1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "identifier", rName1),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_MariaDB(rName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "id", rName2),
+					resource.TestCheckResourceAttr(resourceName, "identifier", rName2),
+					testAccCheckResourceAttrRegionalARN(resourceName, "arn", "rds", fmt.Sprintf("db:%s", rName2)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DbName(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_DbName(rName, "db_name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "db_name", "tfacctest"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfacctest"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_DbName(rName, "name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "db_name", "tfacctest"),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfacctest"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_generatedName(t *testing.T) {
+	var v rds.DBInstance
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_generatedName,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.test", &v),
+					testAccCheckAWSDBInstanceAttributes(&v),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_kmsKey(t *testing.T) {
+	var v rds.DBInstance
+	kmsKeyResourceName := "aws_kms_key.foo"
+	resourceName := "aws_db_instance.bar"
+
+	ri := acctest.RandInt()
+	config := fmt.Sprintf(testAccAWSDBInstanceConfigKmsKeyId, ri)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &v),
+					testAccCheckAWSDBInstanceAttributes(&v),
+					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", kmsKeyResourceName, "arn"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"delete_automated_backups",
+					"final_snapshot_identifier",
+					"password",
+					"skip_final_snapshot",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_subnetGroup(t *testing.T) {
+	var v rds.DBInstance
+	rName := acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfigWithSubnetGroup(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "db_subnet_group_name", "foo-"+rName),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfigWithSubnetGroupUpdated(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "db_subnet_group_name", "bar-"+rName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_optionGroup(t *testing.T) {
+	var v rds.DBInstance
+
+	rName := fmt.Sprintf("tf-option-test-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfigWithOptionGroup(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					testAccCheckAWSDBInstanceAttributes(&v),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "option_group_name", rName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_MajorEngineVersion(t *testing.T) {
+	var v rds.DBInstance
+	dataSourceName := "data.aws_rds_engine_version.test"
+	optionGroupResourceName := "aws_db_option_group.test"
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsEngineVersion(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_MajorEngineVersion(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &v),
+					resource.TestCheckResourceAttr(optionGroupResourceName, "major_engine_version", "5.7"),
+					resource.TestCheckResourceAttrPair(resourceName, "engine_version", dataSourceName, "version"),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", optionGroupResourceName, "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_Default_MySQL(t *testing.T) {
+	var v rds.DBInstance
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_Default(rName, "mysql"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &v),
+					resource.TestMatchResourceAttr(resourceName, "option_group_name", regexp.MustCompile(`^default:mysql-\d+-\d+$`)),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_OptionGroupName_Default(rName, "mysql"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_Default_Postgres(t *testing.T) {
+	var v rds.DBInstance
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_Default(rName, "postgres"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &v),
+					resource.TestMatchResourceAttr(resourceName, "option_group_name", regexp.MustCompile(`^default:postgres-\d+$`)),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_OptionGroupName_Default(rName, "postgres"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_Update(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName(rName, "aws_db_option_group.test1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test1", "name"),
+					resource.TestCheckResourceAttr(resourceName, "option_group_status", "in-sync"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName(rName, "aws_db_option_group.test2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test2", "name"),
+					resource.TestCheckResourceAttr(resourceName, "option_group_status", "in-sync"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_ParameterGroupName_Update(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_ParameterGroupName(rName, "test1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test1", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "parameter_group_name", "aws_db_parameter_group.test1", "name"),
+					resource.TestCheckResourceAttr(resourceName, "option_group_status", "in-sync"),
+					testAccCheckAWSDBInstanceParameterApplyStatusInSync(&dbInstance1),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_ParameterGroupName(rName, "test2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test2", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "parameter_group_name", "aws_db_parameter_group.test2", "name"),
+					resource.TestCheckResourceAttr(resourceName, "option_group_status", "in-sync"),
+					// Associating another parameter group requires a reboot.
+					testAccCheckAWSDBInstanceParameterApplyStatusApplied(&dbInstance2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_Removed(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName(rName, "aws_db_option_group.test1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test1", "name"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_Removed(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "option_group_name", "default:mysql-5-7"),
+					resource.TestCheckResourceAttr(resourceName, "option_group_status", "in-sync"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_OptionGroupName_Removed(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_MajorEngineVersionMismatch(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_EngineVersion(rName, "5.7"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test", "name"),
+				),
+			},
+			{
+				Config:      testAccAWSDBInstanceConfig_OptionGroupName_EngineVersion(rName, "8.0"),
+				ExpectError: regexp.MustCompile(`option_group_name \(` + rName + `\) is for major engine version 5.7 and cannot be used with engine_version 8.0`),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_OptionGroupName_MajorVersionUpgrade(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_MajorVersionUpgrade(rName, "5.7", "test57"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestMatchResourceAttr(resourceName, "engine_version", regexp.MustCompile(`^5\.7\.`)),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test57", "name"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_OptionGroupName_MajorVersionUpgrade(rName, "8.0", "test80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestMatchResourceAttr(resourceName, "engine_version", regexp.MustCompile(`^8\.0\.`)),
+					resource.TestCheckResourceAttrPair(resourceName, "option_group_name", "aws_db_option_group.test80", "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_iamAuth(t *testing.T) {
+	var v rds.DBInstance
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckAWSDBIAMAuth(acctest.RandInt()),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					testAccCheckAWSDBInstanceAttributes(&v),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "iam_database_authentication_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_AllowMajorVersionUpgrade(t *testing.T) {
+	var dbInstance1 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfigAllowMajorVersionUpgrade(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"allow_major_version_upgrade",
+					"apply_immediately",
+					"final_snapshot_identifier",
+					"password",
+					"skip_final_snapshot",
+				},
+			},
+			{
+				Config: testAccAWSDBInstanceConfigAllowMajorVersionUpgrade(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"final_snapshot_identifier",
+					"password",
+					"skip_final_snapshot",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DbSubnetGroupName(t *testing.T) {
+	var dbInstance rds.DBInstance
+	var dbSubnetGroup rds.DBSubnetGroup
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_DbSubnetGroupName(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DbSubnetGroupName_RamShared(t *testing.T) {
+	var dbInstance rds.DBInstance
+	var dbSubnetGroup rds.DBSubnetGroup
+	var providers []*schema.Provider
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccAlternateAccountPreCheck(t)
+			testAccOrganizationsEnabledPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_DbSubnetGroupName_RamShared(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckDBSubnetGroupExists(dbSubnetGroupResourceName, &dbSubnetGroup),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DbSubnetGroupName_AvailabilityZoneMismatch(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// Create the DB subnet group first, so its name is known when
+				// the DB instance is planned.
+				Config: testAccAWSDBInstanceConfig_DbSubnetGroupName_AvailabilityZoneBase(rName),
+			},
+			{
+				Config:      testAccAWSDBInstanceConfig_DbSubnetGroupName_AvailabilityZoneMismatch(rName),
+				ExpectError: regexp.MustCompile(`availability_zone \(.+\) has no subnet in db_subnet_group_name \(` + rName + `\), which covers: `),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DbSubnetGroupName_VpcSecurityGroupIds(t *testing.T) {
+	var dbInstance rds.DBInstance
+	var dbSubnetGroup rds.DBSubnetGroup
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_DbSubnetGroupName_VpcSecurityGroupIds(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_VpcSecurityGroupIds_Default(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	securityGroupDataSourceName := "data.aws_security_group.default"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if !testAccHasDefaultVpc(t) {
+				t.Skip("skipping tests; region does not have a default VPC")
+			}
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_VpcSecurityGroupIds_Default(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "vpc_security_group_ids.#", "1"),
+					tfawsresource.TestCheckTypeSetElemAttrPair(resourceName, "vpc_security_group_ids.*", securityGroupDataSourceName, "id"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_VpcSecurityGroupIds_Default(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DeletionProtection(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_DeletionProtection(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"final_snapshot_identifier",
+					"password",
+					"skip_final_snapshot",
+					"delete_automated_backups",
+				},
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_DeletionProtection(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DeletionProtection_Drift(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_DeletionProtection(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+					testAccCheckAWSDBInstanceModifyDeletionProtection(&dbInstance, true),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_DeletionProtection(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_FinalSnapshotIdentifier(t *testing.T) {
+	var snap rds.DBInstance
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		// testAccCheckAWSDBInstanceSnapshot verifies a database snapshot is
+		// created, and subequently deletes it
+		CheckDestroy: testAccCheckAWSDBInstanceSnapshot,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_FinalSnapshotIdentifier(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.snapshot", &snap),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_FinalSnapshotIdentifier_SkipFinalSnapshot(t *testing.T) {
+	var snap rds.DBInstance
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceNoSnapshot,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_FinalSnapshotIdentifier_SkipFinalSnapshot(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.snapshot", &snap),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_Engine_ForcesReplacement(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_Engine(rName, "mysql"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "engine", "mysql"),
+				),
+			},
+			{
+				Config:             testAccAWSDBInstanceConfig_Engine(rName, "mariadb"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_ApplyImmediately_Default(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_ApplyImmediatelyDefault(rName, "db.t2.micro"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "apply_immediately", "false"),
+					resource.TestCheckResourceAttr(resourceName, "pending_modified_values.#", "0"),
+				),
+			},
+			{
+				// The instance class change is queued for the maintenance window,
+				// so the refreshed instance_class still differs from the configuration.
+				Config: testAccAWSDBInstanceConfig_ApplyImmediatelyDefault(rName, "db.t3.micro"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "apply_immediately", "false"),
+					resource.TestCheckResourceAttr(resourceName, "instance_class", "db.t2.micro"),
+					resource.TestCheckResourceAttr(resourceName, "pending_modified_values.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "pending_modified_values.0.instance_class", "db.t3.micro"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_MultiAZ_AvailabilityZone(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_MultiAZ_AvailabilityZone(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "false"),
+					resource.TestCheckResourceAttrPair(resourceName, "availability_zone", "data.aws_availability_zones.available", "names.0"),
+				),
+			},
+			{
+				// Converting to Multi-AZ may move the primary to another availability
+				// zone, which must not be reported as drift or force replacement.
+				Config: testAccAWSDBInstanceConfig_MultiAZ_AvailabilityZone(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "availability_zone"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_MultiAZ_AvailabilityZone(rName, true),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_MultiAZ_SQLServer(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_MultiAZ_SQLServer(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "false"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_MultiAZ_SQLServer(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "true"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_MultiAZ_SQLServer(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_Tags_DefaultTags(t *testing.T) {
+	var providers []*schema.Provider
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: composeConfig(
+					testAccProviderConfigDefaultTags("providerkey1", "providervalue1"),
+					testAccAWSDBInstanceConfig_Tags(rName, 1),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key0", "value0"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.key0", "value0"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.providerkey1", "providervalue1"),
+				),
+			},
+			{
+				Config: composeConfig(
+					testAccProviderConfigDefaultTags("providerkey1", "providervalue2"),
+					testAccAWSDBInstanceConfig_Tags(rName, 1),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.providerkey1", "providervalue2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_Tags_Many(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_Tags(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_Tags(rName, 15),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "15"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key0", "value0"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key14", "value14"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_Tags(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key0", "value0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DomainIamRoleName_NotFound(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSDBInstanceConfig_DomainIamRoleName(rName),
+				ExpectError: regexp.MustCompile(`domain_iam_role_name \(.+\): IAM role not found`),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_StorageEncrypted_DefaultKmsKey(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_StorageEncrypted(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "true"),
+					testAccMatchResourceAttrRegionalARN(resourceName, "kms_key_id", "kms", regexp.MustCompile(`key/.+`)),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_StorageEncrypted(rName, true),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_StorageEncrypted_EnableOnExisting(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_StorageEncrypted(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "false"),
+				),
+			},
+			{
+				Config:      testAccAWSDBInstanceConfig_StorageEncrypted(rName, true),
+				ExpectError: regexp.MustCompile(`storage_encrypted cannot be enabled on existing unencrypted DB Instance`),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_StorageEncrypted_DisableOnExisting(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_StorageEncrypted(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "true"),
+				),
+			},
+			{
+				Config:      testAccAWSDBInstanceConfig_StorageEncrypted(rName, false),
+				ExpectError: regexp.MustCompile(`storage_encrypted cannot be disabled on existing encrypted DB Instance`),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_ReplicateSourceDb_SourceDeleted(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_SourceDeleted(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "replicate_source_db", rName+"-source"),
+				),
+			},
+			{
+				PreConfig: func() {
+					// Delete the source out of band, which promotes the replica
+					conn := testAccProvider.Meta().(*AWSClient).rdsconn
+					input := &rds.DeleteDBInstanceInput{
+						DBInstanceIdentifier: aws.String(rName + "-source"),
+						SkipFinalSnapshot:    aws.Bool(true),
+					}
+					if _, err := conn.DeleteDBInstance(input); err != nil {
+						t.Fatalf("error deleting source Database Instance: %s", err)
+					}
+					if err := waitUntilAwsDbInstanceIsDeleted(rName+"-source", conn, 40*time.Minute); err != nil {
+						t.Fatalf("error waiting for source Database Instance deletion: %s", err)
+					}
+				},
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_SourceDeleted(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "replicate_source_db", rName+"-source"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_IsAlreadyBeingDeleted(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_MariaDB(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+				),
+			},
+			{
+				PreConfig: func() {
+					// Get Database Instance into deleting state
+					conn := testAccProvider.Meta().(*AWSClient).rdsconn
+					input := &rds.DeleteDBInstanceInput{
+						DBInstanceIdentifier: aws.String(rName),
+						SkipFinalSnapshot:    aws.Bool(true),
+					}
+					_, err := conn.DeleteDBInstance(input)
+					if err != nil {
+						t.Fatalf("error deleting Database Instance: %s", err)
+					}
+				},
+				Config:  testAccAWSDBInstanceConfig_MariaDB(rName),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_EngineVersion_Latest(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dataSourceName := "data.aws_rds_engine_version.test"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccPreCheckAWSRdsEngineVersion(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_EngineVersion_Latest(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "engine_version", dataSourceName, "version"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_EngineVersion_Latest(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_ParameterGroupName_Rebooting(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_ParameterGroupName_PendingReboot(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttrPair(resourceName, "parameter_group_name", "aws_db_parameter_group.test", "name"),
+					// Apply the pending-reboot parameter, leaving the DB instance
+					// rebooting when the next step modifies it.
+					testAccCheckAWSDBInstanceReboot(&dbInstance1),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_ParameterGroupName_PendingReboot(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					testAccCheckAWSDBInstanceParameterApplyStatusInSync(&dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "backup_retention_period", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_ParameterGroupName_NotFound(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSDBInstanceConfig_ParameterGroupName_NotFound(rName),
+				ExpectError: regexp.MustCompile(`parameter_group_name \(` + rName + `\) does not exist in region`),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_CopyTagsToSnapshot(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_CopyTagsToSnapshot(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"final_snapshot_identifier",
+					"password",
+					"skip_final_snapshot",
+					"delete_automated_backups",
+				},
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_CopyTagsToSnapshot(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_ForceDestroy_DeletionProtection_FinalSnapshot(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		// testAccCheckAWSDBInstanceSnapshot verifies the final snapshot was created.
+		CheckDestroy: testAccCheckAWSDBInstanceSnapshot,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_ForceDestroy_DeletionProtection_FinalSnapshot(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+					resource.TestCheckResourceAttr(resourceName, "final_snapshot_identifier", rName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_BackupRetentionPeriod_Enable(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_BackupRetentionPeriod(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "backup_retention_period", "0"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_BackupRetentionPeriod(rName, 7),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "backup_retention_period", "7"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_BackupRetentionPeriod(rName, 7),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_BackupWindow_Removed(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -74,61 +3213,72 @@ func TestAccAWSDBInstance_basic(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig,
+				Config: testAccAWSDBInstanceConfig_BackupWindow(rName, "04:00-04:30"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
-					testAccCheckAWSDBInstanceAttributes(&dbInstance1),
-					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "10"),
-					resource.TestCheckNoResourceAttr(resourceName, "allow_major_version_upgrade"),
-					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "true"),
-					testAccMatchResourceAttrRegionalARN(resourceName, "arn", "rds", regexp.MustCompile(`db:.+`)),
-					resource.TestCheckResourceAttrSet(resourceName, "availability_zone"),
-					resource.TestCheckResourceAttr(resourceName, "backup_retention_period", "0"),
+					resource.TestCheckResourceAttr(resourceName, "backup_window", "04:00-04:30"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_BackupWindow(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
 					resource.TestCheckResourceAttrSet(resourceName, "backup_window"),
-					resource.TestCheckResourceAttrSet(resourceName, "ca_cert_identifier"),
-					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "false"),
-					resource.TestCheckResourceAttr(resourceName, "db_subnet_group_name", "default"),
-					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
-					resource.TestCheckResourceAttr(resourceName, "enabled_cloudwatch_logs_exports.#", "0"),
-					resource.TestCheckResourceAttrSet(resourceName, "endpoint"),
-					resource.TestCheckResourceAttr(resourceName, "engine", "mysql"),
-					resource.TestCheckResourceAttrSet(resourceName, "engine_version"),
-					resource.TestCheckResourceAttrSet(resourceName, "hosted_zone_id"),
-					resource.TestCheckResourceAttr(resourceName, "iam_database_authentication_enabled", "false"),
-					resource.TestCheckResourceAttr(resourceName, "instance_class", "db.t2.micro"),
-					resource.TestCheckResourceAttr(resourceName, "license_model", "general-public-license"),
-					resource.TestCheckResourceAttrSet(resourceName, "maintenance_window"),
-					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "0"),
-					resource.TestCheckResourceAttr(resourceName, "name", "baz"),
-					resource.TestCheckResourceAttr(resourceName, "option_group_name", "default:mysql-5-6"),
-					resource.TestCheckResourceAttr(resourceName, "parameter_group_name", "default.mysql5.6"),
-					resource.TestCheckResourceAttr(resourceName, "port", "3306"),
-					resource.TestCheckResourceAttr(resourceName, "publicly_accessible", "false"),
-					resource.TestCheckResourceAttrSet(resourceName, "resource_id"),
-					resource.TestCheckResourceAttr(resourceName, "status", "available"),
-					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "false"),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
-					resource.TestCheckResourceAttr(resourceName, "username", "foo"),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"final_snapshot_identifier",
-					"password",
-					"skip_final_snapshot",
-					"delete_automated_backups",
+				Config:   testAccAWSDBInstanceConfig_BackupWindow(rName, ""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_Name_Import(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_DbName(rName, "name"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "name", "tfacctest"),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected 1 state: %#v", s)
+					}
+
+					for _, attribute := range []string{"db_name", "name"} {
+						if v := s[0].Attributes[attribute]; v != "tfacctest" {
+							return fmt.Errorf("expected imported %s to be %q, got %q", attribute, "tfacctest", v)
+						}
+					}
+
+					return nil
 				},
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_namePrefix(t *testing.T) {
-	var v rds.DBInstance
+func TestAccAWSDBInstance_StorageType_Io1ToGp2(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -136,20 +3286,35 @@ func TestAccAWSDBInstance_namePrefix(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_namePrefix,
+				Config: testAccAWSDBInstanceConfig_StorageType_Iops(rName, "io1", 1000),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.test", &v),
-					testAccCheckAWSDBInstanceAttributes(&v),
-					resource.TestMatchResourceAttr(
-						"aws_db_instance.test", "identifier", regexp.MustCompile("^tf-test-")),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "iops", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "storage_type", "io1"),
+				),
+			},
+			{
+				Config:      testAccAWSDBInstanceConfig_StorageType_Iops(rName, "gp2", 1000),
+				ExpectError: regexp.MustCompile(`iops can only be set with storage_type io1`),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_StorageType_Iops(rName, "gp2", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "iops", "0"),
+					resource.TestCheckResourceAttr(resourceName, "storage_type", "gp2"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_generatedName(t *testing.T) {
-	var v rds.DBInstance
+func TestAccAWSDBInstance_StorageType_Default(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -157,23 +3322,25 @@ func TestAccAWSDBInstance_generatedName(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_generatedName,
+				Config: testAccAWSDBInstanceConfig_StorageType(rName, ""),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.test", &v),
-					testAccCheckAWSDBInstanceAttributes(&v),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestMatchResourceAttr(resourceName, "storage_type", regexp.MustCompile(`^(gp2|standard)$`)),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_StorageType(rName, ""),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_kmsKey(t *testing.T) {
-	var v rds.DBInstance
-	kmsKeyResourceName := "aws_kms_key.foo"
-	resourceName := "aws_db_instance.bar"
+func TestAccAWSDBInstance_AllocatedStorage_Large(t *testing.T) {
+	var dbInstance rds.DBInstance
 
-	ri := acctest.RandInt()
-	config := fmt.Sprintf(testAccAWSDBInstanceConfigKmsKeyId, ri)
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -181,11 +3348,110 @@ func TestAccAWSDBInstance_kmsKey(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: config,
+				Config: testAccAWSDBInstanceConfig_AllocatedStorage_Large(rName, 1000),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(resourceName, &v),
-					testAccCheckAWSDBInstanceAttributes(&v),
-					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", kmsKeyResourceName, "arn"),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "1000"),
+					resource.TestMatchResourceAttr(resourceName, "status", regexp.MustCompile(`^(available|storage-optimization)$`)),
+					resource.TestCheckResourceAttrSet(resourceName, "endpoint"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_MaxAllocatedStorage(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "10"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "0"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 15),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "15"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_MaxAllocatedStorage_EqualAllocatedStorage(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				// allocated_storage is 5.
+				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "5"),
+					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "0"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 5),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_Password(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			// Password should not be shown in error message
+			{
+				Config:      testAccAWSDBInstanceConfig_Password(rName, "invalid"),
+				ExpectError: regexp.MustCompile(`MasterUserPassword: "\*{8}",`),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_Password(rName, "valid-password"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "password", "valid-password"),
 				),
 			},
 			{
@@ -194,7 +3460,6 @@ func TestAccAWSDBInstance_kmsKey(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
 					"apply_immediately",
-					"delete_automated_backups",
 					"final_snapshot_identifier",
 					"password",
 					"skip_final_snapshot",
@@ -204,9 +3469,11 @@ func TestAccAWSDBInstance_kmsKey(t *testing.T) {
 	})
 }
 
-func TestAccAWSDBInstance_subnetGroup(t *testing.T) {
-	var v rds.DBInstance
-	rName := acctest.RandString(10)
+func TestAccAWSDBInstance_Password_Update(t *testing.T) {
+	var dbInstance1, dbInstance2 rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -214,29 +3481,55 @@ func TestAccAWSDBInstance_subnetGroup(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfigWithSubnetGroup(rName),
+				Config: testAccAWSDBInstanceConfig_Password(rName, "valid-password"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "db_subnet_group_name", "foo-"+rName),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "apply_immediately", "false"),
 				),
 			},
 			{
-				Config: testAccAWSDBInstanceConfigWithSubnetGroupUpdated(rName),
+				Config: testAccAWSDBInstanceConfig_Password(rName, "updated-password"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "db_subnet_group_name", "bar-"+rName),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					testAccCheckAWSDBInstanceMasterUserPasswordApplied(&dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "password", "updated-password"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_optionGroup(t *testing.T) {
-	var v rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	sourceResourceName := "aws_db_instance.source"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "replicate_source_db", sourceResourceName, "identifier"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_ReplicateSourceDb_ReplicaMode_MySQL(t *testing.T) {
+	var dbInstance rds.DBInstance
 
-	rName := fmt.Sprintf("tf-option-test-%d", acctest.RandInt())
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -244,20 +3537,27 @@ func TestAccAWSDBInstance_optionGroup(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfigWithOptionGroup(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					testAccCheckAWSDBInstanceAttributes(&v),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "option_group_name", rName),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "replica_mode", ""),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_ReplicateSourceDb(rName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_iamAuth(t *testing.T) {
-	var v rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb_StorageEncrypted(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	kmsKeyResourceName := "aws_kms_key.test"
+	sourceResourceName := "aws_db_instance.source"
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -265,22 +3565,28 @@ func TestAccAWSDBInstance_iamAuth(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCheckAWSDBIAMAuth(acctest.RandInt()),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_StorageEncrypted(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					testAccCheckAWSDBInstanceAttributes(&v),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "iam_database_authentication_enabled", "true"),
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", kmsKeyResourceName, "arn"),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_ReplicateSourceDb_StorageEncrypted(rName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_AllowMajorVersionUpgrade(t *testing.T) {
-	var dbInstance1 rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb_SourceArn(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -289,41 +3595,23 @@ func TestAccAWSDBInstance_AllowMajorVersionUpgrade(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfigAllowMajorVersionUpgrade(rName, true),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
-					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "true"),
-				),
-			},
-			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"allow_major_version_upgrade",
-					"apply_immediately",
-					"final_snapshot_identifier",
-					"password",
-					"skip_final_snapshot",
-				},
-			},
-			{
-				Config: testAccAWSDBInstanceConfigAllowMajorVersionUpgrade(rName, false),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_SourceArn(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
-					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "false"),
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "replicate_source_db", sourceResourceName, "identifier"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_DbSubnetGroupName(t *testing.T) {
-	var dbInstance rds.DBInstance
-	var dbSubnetGroup rds.DBSubnetGroup
+func TestAccAWSDBInstance_ReplicateSourceDb_AllocatedStorage(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -332,53 +3620,48 @@ func TestAccAWSDBInstance_DbSubnetGroupName(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_DbSubnetGroupName(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AllocatedStorage(rName, 10),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
-					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "10"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_DbSubnetGroupName_RamShared(t *testing.T) {
-	var dbInstance rds.DBInstance
-	var dbSubnetGroup rds.DBSubnetGroup
-	var providers []*schema.Provider
+func TestAccAWSDBInstance_ReplicateSourceDb_AllowMajorVersionUpgrade(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck: func() {
-			testAccPreCheck(t)
-			testAccAlternateAccountPreCheck(t)
-			testAccOrganizationsEnabledPreCheck(t)
-		},
-		ProviderFactories: testAccProviderFactories(&providers),
-		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_DbSubnetGroupName_RamShared(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AllowMajorVersionUpgrade(rName, true),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckDBSubnetGroupExists(dbSubnetGroupResourceName, &dbSubnetGroup),
-					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "true"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_DbSubnetGroupName_VpcSecurityGroupIds(t *testing.T) {
-	var dbInstance rds.DBInstance
-	var dbSubnetGroup rds.DBSubnetGroup
+func TestAccAWSDBInstance_ReplicateSourceDb_AutoMinorVersionUpgrade(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -387,21 +3670,23 @@ func TestAccAWSDBInstance_DbSubnetGroupName_VpcSecurityGroupIds(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_DbSubnetGroupName_VpcSecurityGroupIds(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AutoMinorVersionUpgrade(rName, false),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
-					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "false"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_DeletionProtection(t *testing.T) {
-	var dbInstance rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb_AvailabilityZone(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -410,78 +3695,63 @@ func TestAccAWSDBInstance_DeletionProtection(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_DeletionProtection(rName, true),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
-				),
-			},
-			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"final_snapshot_identifier",
-					"password",
-					"skip_final_snapshot",
-					"delete_automated_backups",
-				},
-			},
-			{
-				Config: testAccAWSDBInstanceConfig_DeletionProtection(rName, false),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AvailabilityZone(rName),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_FinalSnapshotIdentifier(t *testing.T) {
-	var snap rds.DBInstance
-	rInt := acctest.RandInt()
+func TestAccAWSDBInstance_ReplicateSourceDb_BackupRetentionPeriod(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	sourceResourceName := "aws_db_instance.source"
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
-		// testAccCheckAWSDBInstanceSnapshot verifies a database snapshot is
-		// created, and subequently deletes it
-		CheckDestroy: testAccCheckAWSDBInstanceSnapshot,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_FinalSnapshotIdentifier(rInt),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_BackupRetentionPeriod(rName, 1),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.snapshot", &snap),
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "backup_retention_period", "1"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_FinalSnapshotIdentifier_SkipFinalSnapshot(t *testing.T) {
-	var snap rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb_SourceBackupsDisabled(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckAWSDBInstanceNoSnapshot,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_FinalSnapshotIdentifier_SkipFinalSnapshot(),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.snapshot", &snap),
-				),
+				Config:      testAccAWSDBInstanceConfig_ReplicateSourceDb_SourceBackupsDisabled(rName),
+				ExpectError: regexp.MustCompile(`replicate_source_db .* has automated backups disabled`),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_IsAlreadyBeingDeleted(t *testing.T) {
-	var dbInstance rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb_BackupWindow(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -490,113 +3760,123 @@ func TestAccAWSDBInstance_IsAlreadyBeingDeleted(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_MariaDB(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_BackupWindow(rName, "00:00-08:00", "sun:23:00-sun:23:30"),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "backup_window", "00:00-08:00"),
 				),
 			},
-			{
-				PreConfig: func() {
-					// Get Database Instance into deleting state
-					conn := testAccProvider.Meta().(*AWSClient).rdsconn
-					input := &rds.DeleteDBInstanceInput{
-						DBInstanceIdentifier: aws.String(rName),
-						SkipFinalSnapshot:    aws.Bool(true),
-					}
-					_, err := conn.DeleteDBInstance(input)
-					if err != nil {
-						t.Fatalf("error deleting Database Instance: %s", err)
-					}
-				},
-				Config:  testAccAWSDBInstanceConfig_MariaDB(rName),
-				Destroy: true,
-			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_MaxAllocatedStorage(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_DbSubnetGroupName(t *testing.T) {
 	var dbInstance rds.DBInstance
+	var dbSubnetGroup rds.DBSubnetGroup
+	var providers []*schema.Provider
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccMultipleRegionsPreCheck(t)
+			testAccAlternateRegionPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 10),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "10"),
-				),
-			},
-			{
-				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 5),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "0"),
-				),
-			},
-			{
-				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 15),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DbSubnetGroupName(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "15"),
+					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
 				),
 			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_ReplicateSourceDb_DbSubnetGroupName_RamShared(t *testing.T) {
+	var dbInstance rds.DBInstance
+	var dbSubnetGroup rds.DBSubnetGroup
+	var providers []*schema.Provider
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccMultipleRegionsPreCheck(t)
+			testAccAlternateRegionPreCheck(t)
+			testAccAlternateAccountPreCheck(t)
+			testAccOrganizationsEnabledPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_MaxAllocatedStorage(rName, 0),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DbSubnetGroupName_RamShared(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "0"),
+					testAccCheckDBSubnetGroupExists(dbSubnetGroupResourceName, &dbSubnetGroup),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_Password(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_DbSubnetGroupName_VpcSecurityGroupIds(t *testing.T) {
 	var dbInstance rds.DBInstance
+	var dbSubnetGroup rds.DBSubnetGroup
+	var providers []*schema.Provider
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
+	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccMultipleRegionsPreCheck(t)
+			testAccAlternateRegionPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
-			// Password should not be shown in error message
 			{
-				Config:      testAccAWSDBInstanceConfig_Password(rName, "invalid"),
-				ExpectError: regexp.MustCompile(`MasterUserPassword: "\*{8}",`),
-			},
-			{
-				Config: testAccAWSDBInstanceConfig_Password(rName, "valid-password"),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DbSubnetGroupName_VpcSecurityGroupIds(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "password", "valid-password"),
+					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
 				),
 			},
-			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
-				ImportStateVerifyIgnore: []string{
-					"apply_immediately",
-					"final_snapshot_identifier",
-					"password",
-					"skip_final_snapshot",
-				},
-			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_DeletionProtection(t *testing.T) {
+	TestAccSkip(t, "CreateDBInstanceReadReplica API currently ignores DeletionProtection=true with SourceDBInstanceIdentifier set")
+	// --- FAIL: TestAccAWSDBInstance_ReplicateSourceDb_DeletionProtection (1624.88s)
+	//     testing.go:527: Step 0 error: Check failed: Check 4/4 error: aws_db_instance.test: Attribute 'deletion_protection' expected "true", got "false"
+	//
+	// Action=CreateDBInstanceReadReplica&AutoMinorVersionUpgrade=true&CopyTagsToSnapshot=false&DBInstanceClass=db.t2.micro&DBInstanceIdentifier=tf-acc-test-6591588621809891413&DeletionProtection=true&PubliclyAccessible=false&SourceDBInstanceIdentifier=tf-acc-test-6591588621809891413-source&Tags=&Version=2014-10-31
+	// <RestoreDBInstanceFromDBSnapshotResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
+	//   <RestoreDBInstanceFromDBSnapshotResult>
+	//     <DBInstance>
+	//       <DeletionProtection>false</DeletionProtection>
+	//
+	// AWS Support has confirmed this issue and noted that it will be fixed in the future.
+
 	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -609,18 +3889,29 @@ func TestAccAWSDBInstance_ReplicateSourceDb(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DeletionProtection(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+				),
+			},
+			// Ensure we disable deletion protection before attempting to delete :)
+			{
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DeletionProtection(rName, false),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_AllocatedStorage(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_IamDatabaseAuthenticationEnabled(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -633,19 +3924,19 @@ func TestAccAWSDBInstance_ReplicateSourceDb_AllocatedStorage(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AllocatedStorage(rName, 10),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_IamDatabaseAuthenticationEnabled(rName, true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "10"),
+					resource.TestCheckResourceAttr(resourceName, "iam_database_authentication_enabled", "true"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_AllowMajorVersionUpgrade(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_MaintenanceWindow(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -658,19 +3949,19 @@ func TestAccAWSDBInstance_ReplicateSourceDb_AllowMajorVersionUpgrade(t *testing.
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AllowMajorVersionUpgrade(rName, true),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MaintenanceWindow(rName, "00:00-08:00", "sun:23:00-sun:23:30"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "true"),
+					resource.TestCheckResourceAttr(resourceName, "maintenance_window", "sun:23:00-sun:23:30"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_AutoMinorVersionUpgrade(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_MaxAllocatedStorage(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -683,19 +3974,19 @@ func TestAccAWSDBInstance_ReplicateSourceDb_AutoMinorVersionUpgrade(t *testing.T
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AutoMinorVersionUpgrade(rName, false),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MaxAllocatedStorage(rName, 10),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "auto_minor_version_upgrade", "false"),
+					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "10"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_AvailabilityZone(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_Monitoring(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -708,18 +3999,19 @@ func TestAccAWSDBInstance_ReplicateSourceDb_AvailabilityZone(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_AvailabilityZone(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_Monitoring(rName, 5),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "5"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_BackupRetentionPeriod(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_MultiAZ(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -732,23 +4024,22 @@ func TestAccAWSDBInstance_ReplicateSourceDb_BackupRetentionPeriod(t *testing.T)
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_BackupRetentionPeriod(rName, 1),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MultiAZ(rName, true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "backup_retention_period", "1"),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "true"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_BackupWindow(t *testing.T) {
-	var dbInstance, sourceDbInstance rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb_MultiAZ_Update(t *testing.T) {
+	var dbInstance1, dbInstance2, dbInstance3 rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -757,126 +4048,114 @@ func TestAccAWSDBInstance_ReplicateSourceDb_BackupWindow(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_BackupWindow(rName, "00:00-08:00", "sun:23:00-sun:23:30"),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MultiAZ(rName, false),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "backup_window", "00:00-08:00"),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "false"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MultiAZ(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "true"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MultiAZ(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance3),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance2, &dbInstance3),
+					resource.TestCheckResourceAttr(resourceName, "multi_az", "false"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_DbSubnetGroupName(t *testing.T) {
-	var dbInstance rds.DBInstance
-	var dbSubnetGroup rds.DBSubnetGroup
-	var providers []*schema.Provider
+func TestAccAWSDBInstance_ReplicateSourceDb_ParameterGroupName(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck: func() {
-			testAccPreCheck(t)
-			testAccMultipleRegionsPreCheck(t)
-			testAccAlternateRegionPreCheck(t)
-		},
-		ProviderFactories: testAccProviderFactories(&providers),
-		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DbSubnetGroupName(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_ParameterGroupName(rName),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
-					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "parameter_group_name", rName),
+					testAccCheckAWSDBInstanceParameterApplyStatusInSync(&dbInstance),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_DbSubnetGroupName_RamShared(t *testing.T) {
-	var dbInstance rds.DBInstance
-	var dbSubnetGroup rds.DBSubnetGroup
-	var providers []*schema.Provider
+func TestAccAWSDBInstance_ReplicateSourceDb_Port(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck: func() {
-			testAccPreCheck(t)
-			testAccMultipleRegionsPreCheck(t)
-			testAccAlternateRegionPreCheck(t)
-			testAccAlternateAccountPreCheck(t)
-			testAccOrganizationsEnabledPreCheck(t)
-		},
-		ProviderFactories: testAccProviderFactories(&providers),
-		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DbSubnetGroupName_RamShared(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_Port(rName, 9999),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckDBSubnetGroupExists(dbSubnetGroupResourceName, &dbSubnetGroup),
-					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "port", "9999"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_DbSubnetGroupName_VpcSecurityGroupIds(t *testing.T) {
-	var dbInstance rds.DBInstance
-	var dbSubnetGroup rds.DBSubnetGroup
-	var providers []*schema.Provider
+func TestAccAWSDBInstance_ReplicateSourceDb_VpcSecurityGroupIds(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	dbSubnetGroupResourceName := "aws_db_subnet_group.test"
+	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck: func() {
-			testAccPreCheck(t)
-			testAccMultipleRegionsPreCheck(t)
-			testAccAlternateRegionPreCheck(t)
-		},
-		ProviderFactories: testAccProviderFactories(&providers),
-		CheckDestroy:      testAccCheckAWSDBInstanceDestroy,
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DbSubnetGroupName_VpcSecurityGroupIds(rName),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_VpcSecurityGroupIds(rName),
 				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckDBSubnetGroupExists(resourceName, &dbSubnetGroup),
-					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", dbSubnetGroupResourceName, "name"),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "vpc_security_group_ids.#", "1"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_DeletionProtection(t *testing.T) {
-	TestAccSkip(t, "CreateDBInstanceReadReplica API currently ignores DeletionProtection=true with SourceDBInstanceIdentifier set")
-	// --- FAIL: TestAccAWSDBInstance_ReplicateSourceDb_DeletionProtection (1624.88s)
-	//     testing.go:527: Step 0 error: Check failed: Check 4/4 error: aws_db_instance.test: Attribute 'deletion_protection' expected "true", got "false"
-	//
-	// Action=CreateDBInstanceReadReplica&AutoMinorVersionUpgrade=true&CopyTagsToSnapshot=false&DBInstanceClass=db.t2.micro&DBInstanceIdentifier=tf-acc-test-6591588621809891413&DeletionProtection=true&PubliclyAccessible=false&SourceDBInstanceIdentifier=tf-acc-test-6591588621809891413-source&Tags=&Version=2014-10-31
-	// <RestoreDBInstanceFromDBSnapshotResponse xmlns="http://rds.amazonaws.com/doc/2014-10-31/">
-	//   <RestoreDBInstanceFromDBSnapshotResult>
-	//     <DBInstance>
-	//       <DeletionProtection>false</DeletionProtection>
-	//
-	// AWS Support has confirmed this issue and noted that it will be fixed in the future.
-
-	var dbInstance, sourceDbInstance rds.DBInstance
+func TestAccAWSDBInstance_ReplicateSourceDb_CACertificateIdentifier(t *testing.T) {
+	var dbInstance1, dbInstance2, sourceDbInstance1, sourceDbInstance2 rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
+	caName := "rds-ca-2019"
+	certificatesDataSourceName := "data.aws_rds_certificates.test"
 	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
@@ -886,32 +4165,35 @@ func TestAccAWSDBInstance_ReplicateSourceDb_DeletionProtection(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DeletionProtection(rName, true),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier(rName, caName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance1),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance1),
+					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance1, &dbInstance1),
+					resource.TestCheckResourceAttr(sourceResourceName, "ca_cert_identifier", caName),
+					resource.TestCheckResourceAttr(resourceName, "ca_cert_identifier", caName),
 				),
 			},
-			// Ensure we disable deletion protection before attempting to delete :)
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_DeletionProtection(rName, false),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier_Rotated(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance2),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&sourceDbInstance1, &sourceDbInstance2),
+					testAccCheckAWSDBInstanceNotRecreated(&dbInstance1, &dbInstance2),
+					resource.TestCheckResourceAttrPair(sourceResourceName, "ca_cert_identifier", certificatesDataSourceName, "ids.0"),
+					resource.TestCheckResourceAttrPair(resourceName, "ca_cert_identifier", certificatesDataSourceName, "ids.0"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_IamDatabaseAuthenticationEnabled(t *testing.T) {
+func TestAccAWSDBInstance_ReplicateSourceDb_CACertificateIdentifier_Inherited(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
+	caName := "rds-ca-2019"
 	sourceResourceName := "aws_db_instance.source"
 	resourceName := "aws_db_instance.test"
 
@@ -921,24 +4203,28 @@ func TestAccAWSDBInstance_ReplicateSourceDb_IamDatabaseAuthenticationEnabled(t *
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_IamDatabaseAuthenticationEnabled(rName, true),
+				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier_Inherited(rName, caName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "iam_database_authentication_enabled", "true"),
+					resource.TestCheckResourceAttr(sourceResourceName, "ca_cert_identifier", caName),
+					resource.TestCheckResourceAttrPair(resourceName, "ca_cert_identifier", sourceResourceName, "ca_cert_identifier"),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier_Inherited(rName, caName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_MaintenanceWindow(t *testing.T) {
-	var dbInstance, sourceDbInstance rds.DBInstance
-
-	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
-	resourceName := "aws_db_instance.test"
+func TestAccAWSDBInstance_S3Import(t *testing.T) {
+	var snap rds.DBInstance
+	bucket := acctest.RandomWithPrefix("tf-acc-test")
+	uniqueId := acctest.RandomWithPrefix("tf-acc-s3-import-test")
+	bucketPrefix := acctest.RandString(5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -946,24 +4232,17 @@ func TestAccAWSDBInstance_ReplicateSourceDb_MaintenanceWindow(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MaintenanceWindow(rName, "00:00-08:00", "sun:23:00-sun:23:30"),
+				Config: testAccAWSDBInstanceConfig_S3Import(bucket, bucketPrefix, uniqueId),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "maintenance_window", "sun:23:00-sun:23:30"),
+					testAccCheckAWSDBInstanceExists("aws_db_instance.s3", &snap),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_MaxAllocatedStorage(t *testing.T) {
-	var dbInstance, sourceDbInstance rds.DBInstance
-
+func TestAccAWSDBInstance_S3Import_IngestionRoleUntrusted(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
-	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -971,23 +4250,20 @@ func TestAccAWSDBInstance_ReplicateSourceDb_MaxAllocatedStorage(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MaxAllocatedStorage(rName, 10),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "max_allocated_storage", "10"),
-				),
+				Config:      testAccAWSDBInstanceConfig_S3Import_IngestionRoleUntrusted(rName),
+				ExpectError: regexp.MustCompile(`IAM role assume role policy must trust the rds.amazonaws.com service`),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_Monitoring(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
+	var dbSnapshot rds.DBSnapshot
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
+	sourceDbResourceName := "aws_db_instance.source"
+	snapshotResourceName := "aws_db_snapshot.test"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -996,23 +4272,24 @@ func TestAccAWSDBInstance_ReplicateSourceDb_Monitoring(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_Monitoring(rName, 5),
+				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
+					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "5"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_MultiAZ(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier_AllocatedStorage(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
+	var dbSnapshot rds.DBSnapshot
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
+	sourceDbResourceName := "aws_db_instance.source"
+	snapshotResourceName := "aws_db_snapshot.test"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -1021,23 +4298,27 @@ func TestAccAWSDBInstance_ReplicateSourceDb_MultiAZ(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_MultiAZ(rName, true),
+				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_AllocatedStorage(rName, 10),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
+					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "multi_az", "true"),
+					resource.TestCheckResourceAttr(sourceDbResourceName, "allocated_storage", "5"),
+					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "10"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_ParameterGroupName(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier_KmsKeyId(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
+	var dbSnapshot rds.DBSnapshot
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
+	kmsKeyResourceName := "aws_kms_key.test"
+	sourceDbResourceName := "aws_db_instance.source"
+	snapshotResourceName := "aws_db_snapshot.test"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -1046,25 +4327,21 @@ func TestAccAWSDBInstance_ReplicateSourceDb_ParameterGroupName(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_ParameterGroupName(rName),
+				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_KmsKeyId(rName, true),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
+					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "parameter_group_name", rName),
-					testAccCheckAWSDBInstanceParameterApplyStatusInSync(&dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "kms_key_id", kmsKeyResourceName, "arn"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_Port(t *testing.T) {
-	var dbInstance, sourceDbInstance rds.DBInstance
-
+func TestAccAWSDBInstance_SnapshotIdentifier_KmsKeyId_Unencrypted(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
-	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -1072,23 +4349,20 @@ func TestAccAWSDBInstance_ReplicateSourceDb_Port(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_Port(rName, 9999),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "port", "9999"),
-				),
+				Config:      testAccAWSDBInstanceConfig_SnapshotIdentifier_KmsKeyId(rName, false),
+				ExpectError: regexp.MustCompile(`is not encrypted and RDS does not support restoring`),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_VpcSecurityGroupIds(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier_StorageEncrypted(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
+	var dbSnapshot rds.DBSnapshot
 
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	sourceResourceName := "aws_db_instance.source"
+	sourceDbResourceName := "aws_db_instance.source"
+	snapshotResourceName := "aws_db_snapshot.test"
 	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -1097,25 +4371,24 @@ func TestAccAWSDBInstance_ReplicateSourceDb_VpcSecurityGroupIds(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_VpcSecurityGroupIds(rName),
+				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_StorageEncrypted(rName, "null"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
+					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "vpc_security_group_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "storage_encrypted", "true"),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_SnapshotIdentifier_StorageEncrypted(rName, "null"),
+				PlanOnly: true,
+			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_ReplicateSourceDb_CACertificateIdentifier(t *testing.T) {
-	var dbInstance, sourceDbInstance rds.DBInstance
-
+func TestAccAWSDBInstance_SnapshotIdentifier_StorageEncrypted_False(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
-	caName := "rds-ca-2019"
-	sourceResourceName := "aws_db_instance.source"
-	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -1123,24 +4396,19 @@ func TestAccAWSDBInstance_ReplicateSourceDb_CACertificateIdentifier(t *testing.T
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier(rName, caName),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
-					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
-					resource.TestCheckResourceAttr(sourceResourceName, "ca_cert_identifier", caName),
-					resource.TestCheckResourceAttr(resourceName, "ca_cert_identifier", caName),
-				),
+				Config:      testAccAWSDBInstanceConfig_SnapshotIdentifier_StorageEncrypted(rName, "false"),
+				ExpectError: regexp.MustCompile(`storage_encrypted is false, but DB Snapshot .* is encrypted`),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_S3Import(t *testing.T) {
-	var snap rds.DBInstance
-	bucket := acctest.RandomWithPrefix("tf-acc-test")
-	uniqueId := acctest.RandomWithPrefix("tf-acc-s3-import-test")
-	bucketPrefix := acctest.RandString(5)
+func TestAccAWSDBInstance_RestoreToPointInTime_DbSubnetGroupName(t *testing.T) {
+	var dbInstance, sourceDbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	sourceDbResourceName := "aws_db_instance.source"
+	resourceName := "aws_db_instance.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -1148,16 +4416,32 @@ func TestAccAWSDBInstance_S3Import(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_S3Import(bucket, bucketPrefix, uniqueId),
+				Config: testAccAWSDBInstanceConfig_RestoreToPointInTime_DbSubnetGroupName(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.s3", &snap),
+					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "db_subnet_group_name", "aws_db_subnet_group.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "vpc_security_group_ids.#", "1"),
+					tfawsresource.TestCheckTypeSetElemAttrPair(resourceName, "vpc_security_group_ids.*", "aws_security_group.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "engine", sourceDbResourceName, "engine"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"password",
+					"restore_to_point_in_time",
+					"skip_final_snapshot",
+				},
+			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_SnapshotIdentifier(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier_AllocatedStorage_Unset(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 	var dbSnapshot rds.DBSnapshot
 
@@ -1177,13 +4461,14 @@ func TestAccAWSDBInstance_SnapshotIdentifier(t *testing.T) {
 					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
 					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "allocated_storage", sourceDbResourceName, "allocated_storage"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_SnapshotIdentifier_AllocatedStorage(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier_Io1Storage(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 	var dbSnapshot rds.DBSnapshot
 
@@ -1198,19 +4483,19 @@ func TestAccAWSDBInstance_SnapshotIdentifier_AllocatedStorage(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_AllocatedStorage(rName, 10),
+				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_Io1Storage(rName, 1000),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
 					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "10"),
+					resource.TestCheckResourceAttr(resourceName, "iops", "1000"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_SnapshotIdentifier_Io1Storage(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier_AllowMajorVersionUpgrade(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 	var dbSnapshot rds.DBSnapshot
 
@@ -1225,19 +4510,19 @@ func TestAccAWSDBInstance_SnapshotIdentifier_Io1Storage(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_Io1Storage(rName, 1000),
+				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_AllowMajorVersionUpgrade(rName, true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
 					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "iops", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "true"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAWSDBInstance_SnapshotIdentifier_AllowMajorVersionUpgrade(t *testing.T) {
+func TestAccAWSDBInstance_SnapshotIdentifier_EngineVersion_Partial(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 	var dbSnapshot rds.DBSnapshot
 
@@ -1252,14 +4537,19 @@ func TestAccAWSDBInstance_SnapshotIdentifier_AllowMajorVersionUpgrade(t *testing
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_AllowMajorVersionUpgrade(rName, true),
+				Config: testAccAWSDBInstanceConfig_SnapshotIdentifier_EngineVersion(rName, "5.7"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
 					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
-					resource.TestCheckResourceAttr(resourceName, "allow_major_version_upgrade", "true"),
+					resource.TestMatchResourceAttr(resourceName, "engine_version", regexp.MustCompile(`^5\.7\.`)),
+					resource.TestCheckResourceAttrPair(resourceName, "engine_version", sourceDbResourceName, "engine_version"),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_SnapshotIdentifier_EngineVersion(rName, "5.7"),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -1917,6 +5207,94 @@ func TestAccAWSDBInstance_MonitoringInterval(t *testing.T) {
 	})
 }
 
+func TestAccAWSDBInstance_MonitoringInterval_CreateMonitoringRole(t *testing.T) {
+	var dbInstance rds.DBInstance
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDbInstanceConfigMonitoringIntervalCreateMonitoringRole(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "create_monitoring_role", "true"),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "30"),
+					testAccMatchResourceAttrGlobalARN(resourceName, "monitoring_role_arn", "iam", regexp.MustCompile(`role/tf-rds-monitoring-.+`)),
+					testAccCheckAWSDBInstanceMonitoringRoleArn(&dbInstance, resourceName),
+				),
+			},
+			{
+				Config: testAccDbInstanceConfigMonitoringIntervalCreateMonitoringRole(rName, 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "60"),
+					testAccCheckAWSDBInstanceMonitoringRoleArn(&dbInstance, resourceName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_MonitoringInterval_Default(t *testing.T) {
+	var dbInstance rds.DBInstance
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDbInstanceConfigMonitoringRoleArnRemoved(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_role_arn", ""),
+				),
+			},
+			{
+				Config:   testAccDbInstanceConfigMonitoringRoleArnRemoved(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_MonitoringInterval_MonitoringRoleArnRequired(t *testing.T) {
+	var dbInstance rds.DBInstance
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDbInstanceConfigMonitoringIntervalNoRole(rName),
+				ExpectError: regexp.MustCompile(`monitoring_role_arn is required when monitoring_interval \(30\) is greater than 0`),
+			},
+			{
+				Config: testAccDbInstanceConfigMonitoringRoleArnRemoved(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
+				),
+			},
+			{
+				Config:      testAccDbInstanceConfigMonitoringIntervalNoRole(rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`monitoring_role_arn is required when monitoring_interval \(30\) is greater than 0`),
+			},
+		},
+	})
+}
+
 func TestAccAWSDBInstance_MonitoringRoleArn_EnabledToDisabled(t *testing.T) {
 	var dbInstance rds.DBInstance
 	iamRoleResourceName := "aws_iam_role.test"
@@ -1951,6 +5329,15 @@ func TestAccAWSDBInstance_MonitoringRoleArn_EnabledToDisabled(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
 					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "0"),
+					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
+				),
+			},
+			{
+				Config: testAccDbInstanceConfigMonitoringInterval(rName, 30),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "monitoring_interval", "30"),
+					resource.TestCheckResourceAttrPair(resourceName, "monitoring_role_arn", iamRoleResourceName, "arn"),
 				),
 			},
 		},
@@ -1996,6 +5383,22 @@ func TestAccAWSDBInstance_MonitoringRoleArn_EnabledToRemoved(t *testing.T) {
 	})
 }
 
+func TestAccAWSDBInstance_MonitoringRoleArn_Untrusted(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDbInstanceConfigMonitoringRoleArnUntrusted(rName),
+				ExpectError: regexp.MustCompile(`IAM role assume role policy must trust the monitoring.rds.amazonaws.com service`),
+			},
+		},
+	})
+}
+
 func TestAccAWSDBInstance_MonitoringRoleArn_RemovedToEnabled(t *testing.T) {
 	var dbInstance rds.DBInstance
 	iamRoleResourceName := "aws_iam_role.test"
@@ -2035,11 +5438,44 @@ func TestAccAWSDBInstance_MonitoringRoleArn_RemovedToEnabled(t *testing.T) {
 	})
 }
 
-// Regression test for https://github.com/hashicorp/terraform/issues/3760 .
-// We apply a plan, then change just the iops. If the apply succeeds, we
-// consider this a pass, as before in 3760 the request would fail
-func TestAccAWSDBInstance_separate_iops_update(t *testing.T) {
+// Regression test for https://github.com/hashicorp/terraform/issues/3760 .
+// We apply a plan, then change just the iops. If the apply succeeds, we
+// consider this a pass, as before in 3760 the request would fail
+func TestAccAWSDBInstance_separate_iops_update(t *testing.T) {
+	var v rds.DBInstance
+
+	rName := acctest.RandString(5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapshotInstanceConfig_iopsUpdate(rName, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					testAccCheckAWSDBInstanceAttributes(&v),
+				),
+			},
+
+			{
+				Config: testAccSnapshotInstanceConfig_iopsUpdate(rName, 2000),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					testAccCheckAWSDBInstanceAttributes(&v),
+				),
+			},
+		},
+	})
+}
+
+// Changing allocated_storage and iops together sends both in a single modify,
+// after which the instance can be in storage-optimization; a subsequent
+// non-storage change must still apply cleanly.
+func TestAccAWSDBInstance_iops_AllocatedStorage_update(t *testing.T) {
 	var v rds.DBInstance
+	resourceName := "aws_db_instance.bar"
 
 	rName := acctest.RandString(5)
 
@@ -2049,18 +5485,26 @@ func TestAccAWSDBInstance_separate_iops_update(t *testing.T) {
 		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccSnapshotInstanceConfig_iopsUpdate(rName, 1000),
+				Config: testAccSnapshotInstanceConfig_iopsAllocatedStorageUpdate(rName, 200, 1000, "value1"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					testAccCheckAWSDBInstanceAttributes(&v),
+					testAccCheckAWSDBInstanceExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "200"),
+					resource.TestCheckResourceAttr(resourceName, "iops", "1000"),
 				),
 			},
-
 			{
-				Config: testAccSnapshotInstanceConfig_iopsUpdate(rName, 2000),
+				Config: testAccSnapshotInstanceConfig_iopsAllocatedStorageUpdate(rName, 300, 3000, "value1"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					testAccCheckAWSDBInstanceAttributes(&v),
+					testAccCheckAWSDBInstanceExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "allocated_storage", "300"),
+					resource.TestCheckResourceAttr(resourceName, "iops", "3000"),
+				),
+			},
+			{
+				Config: testAccSnapshotInstanceConfig_iopsAllocatedStorageUpdate(rName, 300, 3000, "value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value2"),
 				),
 			},
 		},
@@ -2092,6 +5536,11 @@ func TestAccAWSDBInstance_portUpdate(t *testing.T) {
 					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
 					resource.TestCheckResourceAttr(
 						"aws_db_instance.bar", "port", "3305"),
+					resource.TestCheckResourceAttr(
+						"aws_db_instance.bar", "db_instance_port", "3305"),
+					testAccCheckAWSDBInstanceEndpointPort(&v, 3305),
+					resource.TestMatchResourceAttr(
+						"aws_db_instance.bar", "endpoint", regexp.MustCompile(`:3305$`)),
 				),
 			},
 		},
@@ -2135,7 +5584,7 @@ func TestAccAWSDBInstance_MSSQL_TZ(t *testing.T) {
 }
 
 func TestAccAWSDBInstance_MSSQL_Domain(t *testing.T) {
-	var vBefore, vAfter rds.DBInstance
+	var vBefore, vAfter, vRemoved rds.DBInstance
 	rInt := acctest.RandInt()
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -2165,6 +5614,15 @@ func TestAccAWSDBInstance_MSSQL_Domain(t *testing.T) {
 						"aws_db_instance.mssql", "domain_iam_role_name"),
 				),
 			},
+			{
+				Config: testAccAWSDBMSSQLRemoveDomain(rInt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists("aws_db_instance.mssql", &vRemoved),
+					testAccCheckAWSDBInstanceNotRecreated(&vAfter, &vRemoved),
+					resource.TestCheckResourceAttr("aws_db_instance.mssql", "domain", ""),
+					resource.TestCheckResourceAttr("aws_db_instance.mssql", "domain_iam_role_name", ""),
+				),
+			},
 		},
 	})
 }
@@ -2260,6 +5718,22 @@ func TestAccAWSDBInstance_ec2Classic(t *testing.T) {
 	})
 }
 
+func TestAccAWSDBInstance_ec2Classic_VpcOnly(t *testing.T) {
+	rInt := acctest.RandInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); testAccEC2VPCOnlyPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSDBInstanceConfigEc2Classic(rInt),
+				ExpectError: regexp.MustCompile(`DB security groups are only supported on EC2-Classic`),
+			},
+		},
+	})
+}
+
 func TestAccAWSDBInstance_cloudwatchLogsExportConfiguration(t *testing.T) {
 	var v rds.DBInstance
 
@@ -2306,40 +5780,40 @@ func TestAccAWSDBInstance_cloudwatchLogsExportConfigurationUpdate(t *testing.T)
 				Config: testAccAWSDBInstanceConfigCloudwatchLogsExportConfiguration(rInt),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.0", "audit"),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.1", "error"),
+					testAccCheckAWSDBInstanceCloudwatchLogsExports(&v, []string{"audit", "error"}),
+					resource.TestCheckResourceAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.#", "2"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "audit"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "error"),
 				),
 			},
 			{
 				Config: testAccAWSDBInstanceConfigCloudwatchLogsExportConfigurationAdd(rInt),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.0", "audit"),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.1", "error"),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.2", "general"),
+					testAccCheckAWSDBInstanceCloudwatchLogsExports(&v, []string{"audit", "error", "general"}),
+					resource.TestCheckResourceAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.#", "3"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "audit"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "error"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "general"),
 				),
 			},
 			{
 				Config: testAccAWSDBInstanceConfigCloudwatchLogsExportConfigurationModify(rInt),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.0", "audit"),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.1", "general"),
-					resource.TestCheckResourceAttr(
-						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.2", "slowquery"),
+					testAccCheckAWSDBInstanceCloudwatchLogsExports(&v, []string{"audit", "general", "slowquery"}),
+					resource.TestCheckResourceAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.#", "3"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "audit"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "general"),
+					tfawsresource.TestCheckTypeSetElemAttr("aws_db_instance.bar", "enabled_cloudwatch_logs_exports.*", "slowquery"),
 				),
 			},
 			{
 				Config: testAccAWSDBInstanceConfigCloudwatchLogsExportConfigurationDelete(rInt),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists("aws_db_instance.bar", &v),
+					testAccCheckAWSDBInstanceCloudwatchLogsExports(&v, nil),
+					testAccCheckAWSDBInstanceCloudwatchLogsExportsDisabled(&v, []string{"audit", "general", "slowquery"}),
 					resource.TestCheckResourceAttr(
 						"aws_db_instance.bar", "enabled_cloudwatch_logs_exports.#", "0"),
 				),
@@ -2348,6 +5822,36 @@ func TestAccAWSDBInstance_cloudwatchLogsExportConfigurationUpdate(t *testing.T)
 	})
 }
 
+func TestAccAWSDBInstance_EnabledCloudwatchLogsExports_Reorder(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_EnabledCloudwatchLogsExports_MySQL(rName, `["audit", "error", "general"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstanceCloudwatchLogsExports(&dbInstance, []string{"audit", "error", "general"}),
+					resource.TestCheckResourceAttr(resourceName, "enabled_cloudwatch_logs_exports.#", "3"),
+					tfawsresource.TestCheckTypeSetElemAttr(resourceName, "enabled_cloudwatch_logs_exports.*", "audit"),
+					tfawsresource.TestCheckTypeSetElemAttr(resourceName, "enabled_cloudwatch_logs_exports.*", "error"),
+					tfawsresource.TestCheckTypeSetElemAttr(resourceName, "enabled_cloudwatch_logs_exports.*", "general"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_EnabledCloudwatchLogsExports_MySQL(rName, `["general", "audit", "error"]`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccAWSDBInstance_EnabledCloudwatchLogsExports_MSSQL(t *testing.T) {
 	var dbInstance rds.DBInstance
 
@@ -2464,12 +5968,100 @@ func TestAccAWSDBInstance_NoDeleteAutomatedBackups(t *testing.T) {
 				Config: testAccAWSDBInstanceConfig_NoDeleteAutomatedBackups(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "delete_automated_backups", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_NoDeleteAutomatedBackups_Tags(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-testacc-nodelautobak")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceAutomatedBackups,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_NoDeleteAutomatedBackups_Tags(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "copy_tags_to_snapshot", "true"),
+					resource.TestCheckResourceAttr(resourceName, "delete_automated_backups", "false"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Name", rName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_DeleteAutomatedBackups_Default(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceAutomatedBackupsDeleted,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_BackupRetentionPeriod(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "delete_automated_backups", "true"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"apply_immediately",
+					"final_snapshot_identifier",
+					"password",
+					"skip_final_snapshot",
+				},
+			},
 		},
 	})
 }
 
+func testAccCheckAWSDBInstanceAutomatedBackupsDeleted(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).rdsconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_db_instance" {
+			continue
+		}
+
+		describeOutput, err := conn.DescribeDBInstanceAutomatedBackups(
+			&rds.DescribeDBInstanceAutomatedBackupsInput{
+				DBInstanceIdentifier: aws.String(rs.Primary.ID),
+			})
+
+		if isAWSErr(err, rds.ErrCodeDBInstanceAutomatedBackupNotFoundFault, "") {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if describeOutput != nil && len(describeOutput.DBInstanceAutomatedBackups) != 0 {
+			return fmt.Errorf("Automated backup for %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return testAccCheckAWSDBInstanceDestroy(s)
+}
+
 func testAccCheckAWSDBInstanceAutomatedBackups(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).rdsconn
 
@@ -2561,42 +6153,197 @@ func testAccCheckAWSDBInstanceAttributes_MSSQL(v *rds.DBInstance, tz string) res
 			return fmt.Errorf("bad engine: %#v", *v.Engine)
 		}
 
-		rtz := ""
-		if v.Timezone != nil {
-			rtz = *v.Timezone
+		rtz := ""
+		if v.Timezone != nil {
+			rtz = *v.Timezone
+		}
+
+		if tz != rtz {
+			return fmt.Errorf("Expected (%s) Timezone for MSSQL test, got (%s)", tz, rtz)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSDBInstanceDomainAttributes(domain string, v *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, dm := range v.DomainMemberships {
+			if *dm.FQDN != domain {
+				continue
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("Domain %s not found in domain memberships", domain)
+	}
+}
+
+func testAccCheckAWSDBInstanceParameterApplyStatusInSync(dbInstance *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, dbParameterGroup := range dbInstance.DBParameterGroups {
+			parameterApplyStatus := aws.StringValue(dbParameterGroup.ParameterApplyStatus)
+			if parameterApplyStatus != "in-sync" {
+				id := aws.StringValue(dbInstance.DBInstanceIdentifier)
+				parameterGroupName := aws.StringValue(dbParameterGroup.DBParameterGroupName)
+				return fmt.Errorf("expected DB Instance (%s) Parameter Group (%s) apply status to be: \"in-sync\", got: %q", id, parameterGroupName, parameterApplyStatus)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSDBInstanceParameterApplyStatusApplied checks that no parameter
+// group is still being applied, though it may be pending a reboot.
+func testAccCheckAWSDBInstanceParameterApplyStatusApplied(dbInstance *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, dbParameterGroup := range dbInstance.DBParameterGroups {
+			parameterApplyStatus := aws.StringValue(dbParameterGroup.ParameterApplyStatus)
+			if parameterApplyStatus != "in-sync" && parameterApplyStatus != "pending-reboot" {
+				id := aws.StringValue(dbInstance.DBInstanceIdentifier)
+				parameterGroupName := aws.StringValue(dbParameterGroup.DBParameterGroupName)
+				return fmt.Errorf("expected DB Instance (%s) Parameter Group (%s) apply status to be: \"in-sync\" or \"pending-reboot\", got: %q", id, parameterGroupName, parameterApplyStatus)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSDBInstanceReboot reboots the DB instance without waiting for
+// it to be available again.
+func testAccCheckAWSDBInstanceReboot(v *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).rdsconn
+
+		_, err := conn.RebootDBInstance(&rds.RebootDBInstanceInput{
+			DBInstanceIdentifier: v.DBInstanceIdentifier,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error rebooting DB Instance (%s): %s", aws.StringValue(v.DBInstanceIdentifier), err)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSDBInstanceModifyDeletionProtection toggles deletion protection
+// of the DB instance outside of Terraform.
+func testAccCheckAWSDBInstanceModifyDeletionProtection(v *rds.DBInstance, deletionProtection bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*AWSClient).rdsconn
+
+		_, err := conn.ModifyDBInstance(&rds.ModifyDBInstanceInput{
+			ApplyImmediately:     aws.Bool(true),
+			DBInstanceIdentifier: v.DBInstanceIdentifier,
+			DeletionProtection:   aws.Bool(deletionProtection),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error modifying DB Instance (%s) deletion protection: %s", aws.StringValue(v.DBInstanceIdentifier), err)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSDBInstanceMasterUserPasswordApplied checks that a master user
+// password change is not pending, i.e. the new password is in use.
+func testAccCheckAWSDBInstanceMasterUserPasswordApplied(v *rds.DBInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if v.PendingModifiedValues != nil && v.PendingModifiedValues.MasterUserPassword != nil {
+			return fmt.Errorf("DB Instance (%s) master user password change is still pending", aws.StringValue(v.DBInstanceIdentifier))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSDBInstanceEndpointPort(v *rds.DBInstance, port int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if v.Endpoint == nil {
+			return fmt.Errorf("DB Instance (%s) has no endpoint", aws.StringValue(v.DBInstanceIdentifier))
+		}
+
+		if got := aws.Int64Value(v.Endpoint.Port); got != port {
+			return fmt.Errorf("expected DB Instance (%s) endpoint port %d, got %d", aws.StringValue(v.DBInstanceIdentifier), port, got)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSDBInstanceCloudwatchLogsExports(v *rds.DBInstance, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		got := aws.StringValueSlice(v.EnabledCloudwatchLogsExports)
+		sort.Strings(got)
+
+		if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+			return fmt.Errorf("expected DB Instance (%s) enabled CloudWatch logs exports %q, got %q", aws.StringValue(v.DBInstanceIdentifier), expected, got)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAWSDBInstanceCloudwatchLogsExportsDisabled checks that each of
+// the log types is neither enabled nor pending to be enabled.
+func testAccCheckAWSDBInstanceCloudwatchLogsExportsDisabled(v *rds.DBInstance, logTypes []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		enabled := aws.StringValueSlice(v.EnabledCloudwatchLogsExports)
+
+		if v.PendingModifiedValues != nil && v.PendingModifiedValues.PendingCloudwatchLogsExports != nil {
+			enabled = append(enabled, aws.StringValueSlice(v.PendingModifiedValues.PendingCloudwatchLogsExports.LogTypesToEnable)...)
+		}
+
+		for _, logType := range logTypes {
+			for _, e := range enabled {
+				if e == logType {
+					return fmt.Errorf("expected DB Instance (%s) CloudWatch logs export %q to be disabled", aws.StringValue(v.DBInstanceIdentifier), logType)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAWSDBInstanceMonitoringRoleArn(v *rds.DBInstance, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
 		}
 
-		if tz != rtz {
-			return fmt.Errorf("Expected (%s) Timezone for MSSQL test, got (%s)", tz, rtz)
+		expected := rs.Primary.Attributes["monitoring_role_arn"]
+		if got := aws.StringValue(v.MonitoringRoleArn); got != expected {
+			return fmt.Errorf("expected DB Instance (%s) monitoring role ARN %q, got %q", aws.StringValue(v.DBInstanceIdentifier), expected, got)
 		}
 
 		return nil
 	}
 }
 
-func testAccCheckAWSDBInstanceDomainAttributes(domain string, v *rds.DBInstance) resource.TestCheckFunc {
+func testAccCheckAWSDBInstanceNotRecreated(i, j *rds.DBInstance) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		for _, dm := range v.DomainMemberships {
-			if *dm.FQDN != domain {
-				continue
-			}
-
-			return nil
+		if aws.StringValue(i.DbiResourceId) != aws.StringValue(j.DbiResourceId) {
+			return fmt.Errorf("DB Instance (%s) was recreated", aws.StringValue(j.DBInstanceIdentifier))
 		}
 
-		return fmt.Errorf("Domain %s not found in domain memberships", domain)
+		return nil
 	}
 }
 
-func testAccCheckAWSDBInstanceParameterApplyStatusInSync(dbInstance *rds.DBInstance) resource.TestCheckFunc {
+// testAccCheckAWSDBInstancePerformanceInsightsEnabled verifies Performance
+// Insights is enabled on the DB instance as described by the API once it has
+// been created, rather than only pending.
+func testAccCheckAWSDBInstancePerformanceInsightsEnabled(dbInstance *rds.DBInstance) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		for _, dbParameterGroup := range dbInstance.DBParameterGroups {
-			parameterApplyStatus := aws.StringValue(dbParameterGroup.ParameterApplyStatus)
-			if parameterApplyStatus != "in-sync" {
-				id := aws.StringValue(dbInstance.DBInstanceIdentifier)
-				parameterGroupName := aws.StringValue(dbParameterGroup.DBParameterGroupName)
-				return fmt.Errorf("expected DB Instance (%s) Parameter Group (%s) apply status to be: \"in-sync\", got: %q", id, parameterGroupName, parameterApplyStatus)
-			}
+		if !aws.BoolValue(dbInstance.PerformanceInsightsEnabled) {
+			return fmt.Errorf("DB Instance (%s) Performance Insights not enabled", aws.StringValue(dbInstance.DBInstanceIdentifier))
 		}
 
 		return nil
@@ -2885,6 +6632,22 @@ func TestAccAWSRDSDBInstance_PerformanceInsightsKmsKeyId(t *testing.T) {
 	})
 }
 
+func TestAccAWSRDSDBInstance_PerformanceInsightsKmsKeyId_NotEnabled(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSDBInstancePerformanceInsightsKmsKeyIdNotEnabled(rName),
+				ExpectError: regexp.MustCompile(`performance_insights_kms_key_id .* can only be set when performance_insights_enabled is true`),
+			},
+		},
+	})
+}
+
 func TestAccAWSRDSDBInstance_PerformanceInsightsRetentionPeriod(t *testing.T) {
 	var dbInstance rds.DBInstance
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -2925,6 +6688,39 @@ func TestAccAWSRDSDBInstance_PerformanceInsightsRetentionPeriod(t *testing.T) {
 	})
 }
 
+func TestAccAWSRDSDBInstance_PerformanceInsightsRetentionPeriod_Disabled(t *testing.T) {
+	var dbInstance rds.DBInstance
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_db_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstancePerformanceInsightsEnabled(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "7"),
+				),
+			},
+			{
+				Config: testAccAWSDBInstancePerformanceInsightsDisabledRetentionPeriod(rName, 7),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "false"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstancePerformanceInsightsDisabledRetentionPeriod(rName, 7),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccAWSDBInstance_ReplicateSourceDb_PerformanceInsightsEnabled(t *testing.T) {
 	var dbInstance, sourceDbInstance rds.DBInstance
 
@@ -2943,12 +6739,17 @@ func TestAccAWSDBInstance_ReplicateSourceDb_PerformanceInsightsEnabled(t *testin
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAWSDBInstanceExists(sourceResourceName, &sourceDbInstance),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstancePerformanceInsightsEnabled(&dbInstance),
 					testAccCheckAWSDBInstanceReplicaAttributes(&sourceDbInstance, &dbInstance),
 					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
 					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "7"),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_ReplicateSourceDb_PerformanceInsightsEnabled(rName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -2974,11 +6775,16 @@ func TestAccAWSDBInstance_SnapshotIdentifier_PerformanceInsightsEnabled(t *testi
 					testAccCheckAWSDBInstanceExists(sourceDbResourceName, &sourceDbInstance),
 					testAccCheckDbSnapshotExists(snapshotResourceName, &dbSnapshot),
 					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					testAccCheckAWSDBInstancePerformanceInsightsEnabled(&dbInstance),
 					resource.TestCheckResourceAttr(resourceName, "performance_insights_enabled", "true"),
 					resource.TestCheckResourceAttrPair(resourceName, "performance_insights_kms_key_id", kmsKeyResourceName, "arn"),
 					resource.TestCheckResourceAttr(resourceName, "performance_insights_retention_period", "7"),
 				),
 			},
+			{
+				Config:   testAccAWSDBInstanceConfig_SnapshotIdentifier_PerformanceInsightsEnabled(rName),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -3004,6 +6810,49 @@ func TestAccAWSDBInstance_CACertificateIdentifier(t *testing.T) {
 	})
 }
 
+func TestAccAWSDBInstance_CACertificateIdentifier_NotAvailable(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSDBInstanceConfig_CACertificateIdentifier(rName, "rds-ca-bogus"),
+				ExpectError: regexp.MustCompile(`ca_cert_identifier \(rds-ca-bogus\) is not available in region \(.+\); valid identifiers are: rds-ca-`),
+			},
+		},
+	})
+}
+
+func TestAccAWSDBInstance_CACertificateIdentifier_DataSource(t *testing.T) {
+	var dbInstance rds.DBInstance
+
+	dataSourceName := "data.aws_rds_certificates.test"
+	resourceName := "aws_db_instance.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSDBInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSDBInstanceConfig_CACertificateIdentifier_DataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSDBInstanceExists(resourceName, &dbInstance),
+					resource.TestCheckResourceAttrPair(resourceName, "ca_cert_identifier", dataSourceName, "ids.0"),
+				),
+			},
+			{
+				Config:   testAccAWSDBInstanceConfig_CACertificateIdentifier_DataSource(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 var testAccAWSDBInstanceConfig = `
 resource "aws_db_instance" "bar" {
   allocated_storage       = 10
@@ -3114,19 +6963,244 @@ resource "aws_db_option_group" "test" {
   option_group_description = "Test option group for terraform"
 }
 
-resource "aws_db_instance" "bar" {
-  allocated_storage   = 10
-  engine              = aws_db_option_group.test.engine_name
-  engine_version      = aws_db_option_group.test.major_engine_version
-  identifier          = %[1]q
-  instance_class      = "db.t2.micro"
-  name                = "baz"
-  option_group_name   = aws_db_option_group.test.name
-  password            = "barbarbarbar"
-  skip_final_snapshot = true
-  username            = "foo"
+resource "aws_db_instance" "bar" {
+  allocated_storage   = 10
+  engine              = aws_db_option_group.test.engine_name
+  engine_version      = aws_db_option_group.test.major_engine_version
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  name                = "baz"
+  option_group_name   = aws_db_option_group.test.name
+  password            = "barbarbarbar"
+  skip_final_snapshot = true
+  username            = "foo"
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_OptionGroupName_MajorEngineVersion(rName string) string {
+	return fmt.Sprintf(`
+data "aws_rds_engine_version" "test" {
+  engine  = "mysql"
+  version = "5.7.22"
+}
+
+resource "aws_db_option_group" "test" {
+  engine_name              = data.aws_rds_engine_version.test.engine
+  major_engine_version     = data.aws_rds_engine_version.test.major_engine_version
+  name                     = %[1]q
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = data.aws_rds_engine_version.test.engine
+  engine_version      = data.aws_rds_engine_version.test.version
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  option_group_name   = aws_db_option_group.test.name
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_CACertificateIdentifier_DataSource(rName string) string {
+	return fmt.Sprintf(`
+data "aws_rds_certificates" "test" {
+  latest_valid_till = true
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  ca_cert_identifier  = data.aws_rds_certificates.test.ids[0]
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_CACertificateIdentifier(rName, caCertificateIdentifier string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  ca_cert_identifier  = %[2]q
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+}
+`, rName, caCertificateIdentifier)
+}
+
+func testAccAWSDBInstanceConfig_OptionGroupName(rName, optionGroupResourceName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_option_group" "test1" {
+  engine_name              = "mysql"
+  major_engine_version     = "5.7"
+  name                     = "%[1]s-1"
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_option_group" "test2" {
+  engine_name              = "mysql"
+  major_engine_version     = "5.7"
+  name                     = "%[1]s-2"
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  apply_immediately   = true
+  engine              = %[2]s.engine_name
+  engine_version      = %[2]s.major_engine_version
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  option_group_name   = %[2]s.name
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, optionGroupResourceName)
+}
+
+func testAccAWSDBInstanceConfig_OptionGroupName_ParameterGroupName(rName, suffix string) string {
+	return fmt.Sprintf(`
+resource "aws_db_option_group" "test1" {
+  engine_name              = "mysql"
+  major_engine_version     = "5.7"
+  name                     = "%[1]s-1"
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_option_group" "test2" {
+  engine_name              = "mysql"
+  major_engine_version     = "5.7"
+  name                     = "%[1]s-2"
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_parameter_group" "test1" {
+  family = "mysql5.7"
+  name   = "%[1]s-1"
+
+  parameter {
+    name  = "character_set_server"
+    value = "utf8"
+  }
+}
+
+resource "aws_db_parameter_group" "test2" {
+  family = "mysql5.7"
+  name   = "%[1]s-2"
+
+  parameter {
+    name  = "character_set_server"
+    value = "utf8mb4"
+  }
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage    = 5
+  apply_immediately    = true
+  engine               = aws_db_option_group.%[2]s.engine_name
+  engine_version       = aws_db_option_group.%[2]s.major_engine_version
+  identifier           = %[1]q
+  instance_class       = "db.t2.micro"
+  option_group_name    = aws_db_option_group.%[2]s.name
+  parameter_group_name = aws_db_parameter_group.%[2]s.name
+  password             = "avoid-plaintext-passwords"
+  username             = "tfacctest"
+  skip_final_snapshot  = true
+}
+`, rName, suffix)
+}
+
+func testAccAWSDBInstanceConfig_OptionGroupName_Removed(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_option_group" "test1" {
+  engine_name              = "mysql"
+  major_engine_version     = "5.7"
+  name                     = "%[1]s-1"
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  apply_immediately   = true
+  engine              = aws_db_option_group.test1.engine_name
+  engine_version      = aws_db_option_group.test1.major_engine_version
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_OptionGroupName_EngineVersion(rName, engineVersion string) string {
+	return fmt.Sprintf(`
+resource "aws_db_option_group" "test" {
+  engine_name              = "mysql"
+  major_engine_version     = "5.7"
+  name                     = %[1]q
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage           = 5
+  allow_major_version_upgrade = true
+  apply_immediately           = true
+  engine                      = aws_db_option_group.test.engine_name
+  engine_version              = %[2]q
+  identifier                  = %[1]q
+  instance_class              = "db.t2.micro"
+  option_group_name           = aws_db_option_group.test.name
+  password                    = "avoid-plaintext-passwords"
+  username                    = "tfacctest"
+  skip_final_snapshot         = true
+}
+`, rName, engineVersion)
+}
+
+func testAccAWSDBInstanceConfig_OptionGroupName_MajorVersionUpgrade(rName, engineVersion, optionGroupResourceName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_option_group" "test57" {
+  engine_name              = "mysql"
+  major_engine_version     = "5.7"
+  name                     = "%[1]s-57"
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_option_group" "test80" {
+  engine_name              = "mysql"
+  major_engine_version     = "8.0"
+  name                     = "%[1]s-80"
+  option_group_description = "Test option group for terraform"
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage           = 5
+  allow_major_version_upgrade = true
+  apply_immediately           = true
+  engine                      = "mysql"
+  engine_version              = %[2]q
+  identifier                  = %[1]q
+  instance_class              = "db.t2.micro"
+  option_group_name           = aws_db_option_group.%[3]s.name
+  password                    = "avoid-plaintext-passwords"
+  username                    = "tfacctest"
+  skip_final_snapshot         = true
 }
-`, rName)
+`, rName, engineVersion, optionGroupResourceName)
 }
 
 func testAccCheckAWSDBIAMAuth(n int) string {
@@ -3306,6 +7380,52 @@ resource "aws_db_instance" "s3" {
 `, bucketName, bucketPrefix, uniqueId, uniqueId, uniqueId, uniqueId, uniqueId, bucketPrefix)
 }
 
+func testAccAWSDBInstanceConfig_S3Import_IngestionRoleUntrusted(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_s3_bucket" "test" {
+  bucket = %[1]q
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "ec2.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  engine_version      = "5.6"
+  identifier          = %[1]q
+  instance_class      = "db.t2.small"
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+
+  s3_import {
+    source_engine         = "mysql"
+    source_engine_version = "5.6"
+
+    bucket_name    = aws_s3_bucket.test.bucket
+    ingestion_role = aws_iam_role.test.arn
+  }
+}
+`, rName)
+}
+
 func testAccAWSDBInstanceConfig_FinalSnapshotIdentifier(rInt int) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "snapshot" {
@@ -3333,6 +7453,22 @@ resource "aws_db_instance" "snapshot" {
 `, rInt, rInt)
 }
 
+func testAccDbInstanceConfigMonitoringIntervalCreateMonitoringRole(rName string, monitoringInterval int) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage      = 5
+  create_monitoring_role = true
+  engine                 = "mysql"
+  identifier             = %[1]q
+  instance_class         = "db.t2.micro"
+  monitoring_interval    = %[2]d
+  password               = "avoid-plaintext-passwords"
+  skip_final_snapshot    = true
+  username               = "tfacctest"
+}
+`, rName, monitoringInterval)
+}
+
 func testAccDbInstanceConfigMonitoringInterval(rName string, monitoringInterval int) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {
@@ -3381,6 +7517,41 @@ resource "aws_db_instance" "test" {
 `, rName, monitoringInterval)
 }
 
+func testAccDbInstanceConfigMonitoringRoleArnUntrusted(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": "rds.amazonaws.com"
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  monitoring_interval = 30
+  monitoring_role_arn = aws_iam_role.test.arn
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+}
+`, rName)
+}
+
 func testAccDbInstanceConfigMonitoringRoleArnRemoved(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "test" {
@@ -3397,6 +7568,23 @@ resource "aws_db_instance" "test" {
 `, rName)
 }
 
+func testAccDbInstanceConfigMonitoringIntervalNoRole(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  engine_version      = "5.6.35"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  monitoring_interval = 30
+  name                = "baz"
+  password            = "barbarbarbar"
+  skip_final_snapshot = true
+  username            = "foo"
+}
+`, rName)
+}
+
 func testAccDbInstanceConfigMonitoringRoleArn(rName string) string {
 	return fmt.Sprintf(`
 data "aws_partition" "current" {
@@ -3467,6 +7655,32 @@ resource "aws_db_instance" "bar" {
 `, rName, iops)
 }
 
+func testAccSnapshotInstanceConfig_iopsAllocatedStorageUpdate(rName string, allocatedStorage, iops int, tagValue string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "bar" {
+  identifier           = "mydb-rds-%[1]s"
+  engine               = "mysql"
+  engine_version       = "5.6.35"
+  instance_class       = "db.t2.micro"
+  name                 = "mydb"
+  username             = "foo"
+  password             = "barbarbar"
+  parameter_group_name = "default.mysql5.6"
+  skip_final_snapshot  = true
+
+  apply_immediately = true
+
+  storage_type      = "io1"
+  allocated_storage = %[2]d
+  iops              = %[3]d
+
+  tags = {
+    key1 = %[4]q
+  }
+}
+`, rName, allocatedStorage, iops, tagValue)
+}
+
 func testAccSnapshotInstanceConfig_mysqlPort(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "bar" {
@@ -3939,7 +8153,133 @@ resource "aws_iam_role_policy_attachment" "attatch-policy" {
 `, rInt, rInt, rInt, rInt)
 }
 
-func testAccAWSDBMSSQLUpdateDomain(rInt int) string {
+func testAccAWSDBMSSQLUpdateDomain(rInt int) string {
+	return fmt.Sprintf(`
+resource "aws_vpc" "foo" {
+  cidr_block           = "10.1.0.0/16"
+  enable_dns_hostnames = true
+
+  tags = {
+    Name = "terraform-testacc-db-instance-mssql-domain"
+  }
+}
+
+resource "aws_db_subnet_group" "rds_one" {
+  name        = "tf_acc_test_%d"
+  description = "db subnets for rds_one"
+
+  subnet_ids = [aws_subnet.main.id, aws_subnet.other.id]
+}
+
+resource "aws_subnet" "main" {
+  vpc_id            = aws_vpc.foo.id
+  availability_zone = "us-west-2a"
+  cidr_block        = "10.1.1.0/24"
+
+  tags = {
+    Name = "tf-acc-db-instance-mssql-domain-main"
+  }
+}
+
+resource "aws_subnet" "other" {
+  vpc_id            = aws_vpc.foo.id
+  availability_zone = "us-west-2b"
+  cidr_block        = "10.1.2.0/24"
+
+  tags = {
+    Name = "tf-acc-db-instance-mssql-domain-other"
+  }
+}
+
+resource "aws_db_instance" "mssql" {
+  identifier = "tf-test-mssql-%d"
+
+  db_subnet_group_name = aws_db_subnet_group.rds_one.name
+
+  instance_class          = "db.t2.micro"
+  allocated_storage       = 20
+  username                = "somecrazyusername"
+  password                = "somecrazypassword"
+  engine                  = "sqlserver-ex"
+  backup_retention_period = 0
+  skip_final_snapshot     = true
+  apply_immediately       = true
+
+  domain               = aws_directory_service_directory.bar.id
+  domain_iam_role_name = aws_iam_role.role.name
+
+  vpc_security_group_ids = [aws_security_group.rds-mssql.id]
+}
+
+resource "aws_security_group" "rds-mssql" {
+  name = "tf-rds-mssql-test-%d"
+
+  description = "TF Testing"
+  vpc_id      = aws_vpc.foo.id
+}
+
+resource "aws_security_group_rule" "rds-mssql-1" {
+  type        = "egress"
+  from_port   = 0
+  to_port     = 0
+  protocol    = "-1"
+  cidr_blocks = ["0.0.0.0/0"]
+
+  security_group_id = aws_security_group.rds-mssql.id
+}
+
+resource "aws_directory_service_directory" "foo" {
+  name     = "terraformtesting.com"
+  password = "SuperSecretPassw0rd"
+  type     = "MicrosoftAD"
+  edition  = "Standard"
+
+  vpc_settings {
+    vpc_id     = aws_vpc.foo.id
+    subnet_ids = [aws_subnet.main.id, aws_subnet.other.id]
+  }
+}
+
+resource "aws_directory_service_directory" "bar" {
+  name     = "corp.notexample.com"
+  password = "SuperSecretPassw0rd"
+  type     = "MicrosoftAD"
+  edition  = "Standard"
+
+  vpc_settings {
+    vpc_id     = aws_vpc.foo.id
+    subnet_ids = [aws_subnet.main.id, aws_subnet.other.id]
+  }
+}
+
+resource "aws_iam_role" "role" {
+  name = "tf-acc-db-instance-mssql-domain-role-%d"
+
+  assume_role_policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Action": "sts:AssumeRole",
+      "Principal": {
+        "Service": "rds.amazonaws.com"
+      },
+      "Effect": "Allow",
+      "Sid": ""
+    }
+  ]
+}
+EOF
+}
+
+resource "aws_iam_role_policy_attachment" "attatch-policy" {
+  role       = aws_iam_role.role.name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonRDSDirectoryServiceAccess"
+}
+`, rInt, rInt, rInt, rInt)
+}
+
+func testAccAWSDBMSSQLRemoveDomain(rInt int) string {
 	return fmt.Sprintf(`
 resource "aws_vpc" "foo" {
   cidr_block           = "10.1.0.0/16"
@@ -3991,9 +8331,6 @@ resource "aws_db_instance" "mssql" {
   skip_final_snapshot     = true
   apply_immediately       = true
 
-  domain               = aws_directory_service_directory.bar.id
-  domain_iam_role_name = aws_iam_role.role.name
-
   vpc_security_group_ids = [aws_security_group.rds-mssql.id]
 }
 
@@ -4576,6 +8913,21 @@ resource "aws_db_instance" "bar" {
 `, rInt)
 }
 
+func testAccAWSDBInstanceConfig_DbName(rName, attribute string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  %[2]s = "tfacctest"
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, attribute)
+}
+
 func testAccAWSDBInstanceConfig_MariaDB(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "test" {
@@ -4590,6 +8942,132 @@ resource "aws_db_instance" "test" {
 `, rName)
 }
 
+func testAccAWSDBInstanceConfig_Engine(rName, engine string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = %[2]q
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, engine)
+}
+
+func testAccAWSDBInstanceConfig_OptionGroupName_Default(rName, engine string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 20
+  engine              = %[2]q
+  identifier          = %[1]q
+  instance_class      = "db.t3.micro"
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, engine)
+}
+
+func testAccAWSDBInstanceConfig_ApplyImmediatelyDefault(rName, instanceClass string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = %[2]q
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, instanceClass)
+}
+
+func testAccAWSDBInstanceConfig_MultiAZ_AvailabilityZone(rName string, multiAz bool) string {
+	return composeConfig(testAccAvailableAZsNoOptInConfig(), fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  apply_immediately   = true
+  availability_zone   = data.aws_availability_zones.available.names[0]
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  multi_az            = %[2]t
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, multiAz))
+}
+
+func testAccAWSDBInstanceConfig_MultiAZ_SQLServer(rName string, multiAz bool) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage       = 20
+  apply_immediately       = true
+  # InvalidParameterValue: Mirroring cannot be applied to instances with backup retention set to zero.
+  backup_retention_period = 1
+  engine                  = "sqlserver-se"
+  identifier              = %[1]q
+  instance_class          = "db.m4.large"
+  license_model           = "license-included"
+  multi_az                = %[2]t
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+`, rName, multiAz)
+}
+
+func testAccAWSDBInstanceConfig_Tags(rName string, tagCount int) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+
+  tags = { for i in range(%[2]d) : "key${i}" => "value${i}" }
+}
+`, rName, tagCount)
+}
+
+func testAccAWSDBInstanceConfig_DomainIamRoleName(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage    = 20
+  domain               = "d-1234567890"
+  domain_iam_role_name = "%[1]s-nonexistent"
+  engine               = "sqlserver-ex"
+  identifier           = %[1]q
+  instance_class       = "db.t2.micro"
+  license_model        = "license-included"
+  password             = "avoid-plaintext-passwords"
+  username             = "tfacctest"
+  skip_final_snapshot  = true
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_StorageEncrypted(rName string, storageEncrypted bool) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t3.micro"
+  password            = "avoid-plaintext-passwords"
+  storage_encrypted   = %[2]t
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, storageEncrypted)
+}
+
 func testAccAWSDBInstanceConfig_DbSubnetGroupName(rName string) string {
 	return fmt.Sprintf(`
 data "aws_availability_zones" "available" {
@@ -4639,6 +9117,62 @@ resource "aws_db_instance" "test" {
 `, rName)
 }
 
+func testAccAWSDBInstanceConfig_DbSubnetGroupName_AvailabilityZoneBase(rName string) string {
+	return fmt.Sprintf(`
+data "aws_availability_zones" "available" {
+  state = "available"
+
+  filter {
+    name   = "opt-in-status"
+    values = ["opt-in-not-required"]
+  }
+}
+
+resource "aws_vpc" "test" {
+  cidr_block = "10.0.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_subnet" "test" {
+  count = 2
+
+  availability_zone = data.aws_availability_zones.available.names[count.index]
+  cidr_block        = "10.0.${count.index}.0/24"
+  vpc_id            = aws_vpc.test.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_db_subnet_group" "test" {
+  name       = %[1]q
+  subnet_ids = aws_subnet.test[*].id
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_DbSubnetGroupName_AvailabilityZoneMismatch(rName string) string {
+	return composeConfig(
+		testAccAWSDBInstanceConfig_DbSubnetGroupName_AvailabilityZoneBase(rName),
+		fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage    = 5
+  availability_zone    = data.aws_availability_zones.available.names[2]
+  db_subnet_group_name = aws_db_subnet_group.test.name
+  engine               = "mysql"
+  identifier           = %[1]q
+  instance_class       = "db.t2.micro"
+  password             = "avoid-plaintext-passwords"
+  username             = "tfacctest"
+  skip_final_snapshot  = true
+}
+`, rName))
+}
+
 func testAccAWSDBInstanceConfig_DbSubnetGroupName_RamShared(rName string) string {
 	return testAccAlternateAccountProviderConfig() + fmt.Sprintf(`
 data "aws_availability_zones" "available" {
@@ -4726,6 +9260,29 @@ resource "aws_db_instance" "test" {
 `, rName)
 }
 
+func testAccAWSDBInstanceConfig_VpcSecurityGroupIds_Default(rName string) string {
+	return fmt.Sprintf(`
+data "aws_vpc" "default" {
+  default = true
+}
+
+data "aws_security_group" "default" {
+  name   = "default"
+  vpc_id = data.aws_vpc.default.id
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+}
+`, rName)
+}
+
 func testAccAWSDBInstanceConfig_DbSubnetGroupName_VpcSecurityGroupIds(rName string) string {
 	return fmt.Sprintf(`
 data "aws_availability_zones" "available" {
@@ -4796,6 +9353,21 @@ resource "aws_db_instance" "test" {
 `, deletionProtection, rName)
 }
 
+func testAccAWSDBInstanceConfig_EnabledCloudwatchLogsExports_MySQL(rName, logTypes string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage               = 5
+  enabled_cloudwatch_logs_exports = %[2]s
+  engine                          = "mysql"
+  identifier                      = %[1]q
+  instance_class                  = "db.t2.micro"
+  password                        = "avoid-plaintext-passwords"
+  username                        = "tfacctest"
+  skip_final_snapshot             = true
+}
+`, rName, logTypes)
+}
+
 func testAccAWSDBInstanceConfig_EnabledCloudwatchLogsExports_Oracle(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "test" {
@@ -4857,21 +9429,102 @@ resource "aws_db_instance" "test" {
 `, rName, maxAllocatedStorage)
 }
 
-func testAccAWSDBInstanceConfig_Password(rName, password string) string {
-	return fmt.Sprintf(`
+func testAccAWSDBInstanceConfig_Password(rName, password string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = %[2]q
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+`, rName, password)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier          = %q
+  instance_class      = aws_db_instance.source.instance_class
+  replicate_source_db = aws_db_instance.source.id
+  skip_final_snapshot = true
+}
+`, rName, rName)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_StorageEncrypted(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+  description             = %[1]q
+  deletion_window_in_days = 7
+}
+
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%[1]s-source"
+  instance_class          = "db.t3.micro"
+  kms_key_id              = aws_kms_key.test.arn
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+  storage_encrypted       = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier          = %[1]q
+  instance_class      = aws_db_instance.source.instance_class
+  replicate_source_db = aws_db_instance.source.id
+  skip_final_snapshot = true
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_SourceDeleted(rName string, withSource bool) string {
+	source, dependsOn := "", ""
+	if withSource {
+		dependsOn = "depends_on = [aws_db_instance.source]"
+		source = fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+`, rName)
+	}
+
+	return source + fmt.Sprintf(`
 resource "aws_db_instance" "test" {
-  allocated_storage   = 5
-  engine              = "mysql"
+  %[2]s
+
   identifier          = %[1]q
   instance_class      = "db.t2.micro"
-  password            = %[2]q
-  username            = "tfacctest"
+  replicate_source_db = "%[1]s-source"
   skip_final_snapshot = true
 }
-`, rName, password)
+`, rName, dependsOn)
 }
 
-func testAccAWSDBInstanceConfig_ReplicateSourceDb(rName string) string {
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_SourceArn(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "source" {
   allocated_storage       = 5
@@ -4887,7 +9540,7 @@ resource "aws_db_instance" "source" {
 resource "aws_db_instance" "test" {
   identifier          = %q
   instance_class      = aws_db_instance.source.instance_class
-  replicate_source_db = aws_db_instance.source.id
+  replicate_source_db = aws_db_instance.source.arn
   skip_final_snapshot = true
 }
 `, rName, rName)
@@ -5017,6 +9670,28 @@ resource "aws_db_instance" "test" {
 `, rName, backupRetentionPeriod, rName)
 }
 
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_SourceBackupsDisabled(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 0
+  engine                  = "mysql"
+  identifier              = "%[1]s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier          = %[1]q
+  instance_class      = aws_db_instance.source.instance_class
+  replicate_source_db = aws_db_instance.source.id
+  skip_final_snapshot = true
+}
+`, rName)
+}
+
 // We provide maintenance_window to prevent the following error from a randomly selected window:
 // InvalidParameterValue: The backup window and maintenance window must not overlap.
 func testAccAWSDBInstanceConfig_ReplicateSourceDb_BackupWindow(rName, backupWindow, maintenanceWindow string) string {
@@ -5552,6 +10227,7 @@ resource "aws_db_instance" "source" {
 }
 
 resource "aws_db_instance" "test" {
+  apply_immediately   = true
   identifier          = %q
   instance_class      = aws_db_instance.source.instance_class
   multi_az            = %t
@@ -5581,123 +10257,494 @@ resource "aws_db_instance" "source" {
   identifier              = "%s-source"
   instance_class          = "db.t2.micro"
   password                = "avoid-plaintext-passwords"
-  username                = "tfacctest"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier           = %q
+  instance_class       = aws_db_instance.source.instance_class
+  parameter_group_name = aws_db_parameter_group.test.id
+  replicate_source_db  = aws_db_instance.source.id
+  skip_final_snapshot  = true
+}
+`, rName, rName, rName)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_Port(rName string, port int) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier          = %q
+  instance_class      = aws_db_instance.source.instance_class
+  port                = %d
+  replicate_source_db = aws_db_instance.source.id
+  skip_final_snapshot = true
+}
+`, rName, rName, port)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_VpcSecurityGroupIds(rName string) string {
+	return fmt.Sprintf(`
+data "aws_vpc" "default" {
+  default = true
+}
+
+resource "aws_security_group" "test" {
+  name   = %q
+  vpc_id = data.aws_vpc.default.id
+}
+
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier             = %q
+  instance_class         = aws_db_instance.source.instance_class
+  replicate_source_db    = aws_db_instance.source.id
+  skip_final_snapshot    = true
+  vpc_security_group_ids = [aws_security_group.test.id]
+}
+`, rName, rName, rName)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier(rName string, caName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  ca_cert_identifier      = %q
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier          = %q
+  instance_class      = aws_db_instance.source.instance_class
+  replicate_source_db = aws_db_instance.source.id
+  ca_cert_identifier  = %q
+  skip_final_snapshot = true
+}
+`, rName, caName, rName, caName)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier_Rotated(rName string) string {
+	return fmt.Sprintf(`
+data "aws_rds_certificates" "test" {
+  latest_valid_till = true
+}
+
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  apply_immediately       = true
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%[1]s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  ca_cert_identifier      = data.aws_rds_certificates.test.ids[0]
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  apply_immediately   = true
+  identifier          = %[1]q
+  instance_class      = aws_db_instance.source.instance_class
+  replicate_source_db = aws_db_instance.source.id
+  ca_cert_identifier  = data.aws_rds_certificates.test.ids[0]
+  skip_final_snapshot = true
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier_Inherited(rName string, caName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  ca_cert_identifier      = %q
+  skip_final_snapshot     = true
+}
+
+resource "aws_db_instance" "test" {
+  identifier          = %q
+  instance_class      = aws_db_instance.source.instance_class
+  replicate_source_db = aws_db_instance.source.id
+  skip_final_snapshot = true
+}
+`, rName, caName, rName)
+}
+
+func testAccAWSDBInstanceConfig_EngineVersion_Latest(rName string) string {
+	return fmt.Sprintf(`
+data "aws_rds_engine_version" "test" {
+  engine = "mysql"
+  latest = true
+}
+
+data "aws_rds_orderable_db_instance" "test" {
+  engine                        = data.aws_rds_engine_version.test.engine
+  engine_version                = data.aws_rds_engine_version.test.version
+  preferred_db_instance_classes = ["db.t3.micro", "db.t2.micro", "db.t3.small"]
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage   = 10
+  engine              = data.aws_rds_engine_version.test.engine
+  engine_version      = data.aws_rds_engine_version.test.version
+  identifier          = %q
+  instance_class      = data.aws_rds_orderable_db_instance.test.db_instance_class
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_ParameterGroupName_PendingReboot(rName string, backupRetentionPeriod int) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  family = "mysql5.7"
+  name   = %[1]q
+
+  parameter {
+    apply_method = "pending-reboot"
+    name         = "performance_schema"
+    value        = 1
+  }
+}
+
+resource "aws_db_instance" "test" {
+  allocated_storage       = 5
+  apply_immediately       = true
+  backup_retention_period = %[2]d
+  engine                  = "mysql"
+  engine_version          = "5.7"
+  identifier              = %[1]q
+  instance_class          = "db.t2.micro"
+  parameter_group_name    = aws_db_parameter_group.test.id
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+`, rName, backupRetentionPeriod)
+}
+
+func testAccAWSDBInstanceConfig_ParameterGroupName_NotFound(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage    = 10
+  engine               = "mysql"
+  identifier           = %[1]q
+  instance_class       = "db.t2.micro"
+  parameter_group_name = %[1]q
+  password             = "avoid-plaintext-passwords"
+  skip_final_snapshot  = true
+  username             = "tfacctest"
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_CopyTagsToSnapshot(rName string, copyTagsToSnapshot bool) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage     = 10
+  apply_immediately     = true
+  copy_tags_to_snapshot = %[2]t
+  engine                = "mysql"
+  identifier            = %[1]q
+  instance_class        = "db.t2.micro"
+  password              = "avoid-plaintext-passwords"
+  skip_final_snapshot   = true
+  username              = "tfacctest"
+}
+`, rName, copyTagsToSnapshot)
+}
+
+func testAccAWSDBInstanceConfig_ForceDestroy_DeletionProtection_FinalSnapshot(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage         = 10
+  backup_retention_period   = 1
+  copy_tags_to_snapshot     = true
+  deletion_protection       = true
+  engine                    = "mysql"
+  final_snapshot_identifier = %[1]q
+  force_destroy             = true
+  identifier                = %[1]q
+  instance_class            = "db.t2.micro"
+  password                  = "avoid-plaintext-passwords"
+  username                  = "tfacctest"
+
+  tags = {
+    Name = "tf-tags-db"
+  }
+}
+`, rName)
+}
+
+func testAccAWSDBInstanceConfig_BackupRetentionPeriod(rName string, backupRetentionPeriod int) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage       = 10
+  apply_immediately       = true
+  backup_retention_period = %[2]d
+  engine                  = "mysql"
+  identifier              = %[1]q
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
   skip_final_snapshot     = true
+  username                = "tfacctest"
 }
-
-resource "aws_db_instance" "test" {
-  identifier           = %q
-  instance_class       = aws_db_instance.source.instance_class
-  parameter_group_name = aws_db_parameter_group.test.id
-  replicate_source_db  = aws_db_instance.source.id
-  skip_final_snapshot  = true
-}
-`, rName, rName, rName)
+`, rName, backupRetentionPeriod)
 }
 
-func testAccAWSDBInstanceConfig_ReplicateSourceDb_Port(rName string, port int) string {
+func testAccAWSDBInstanceConfig_BackupWindow(rName, backupWindow string) string {
+	if backupWindow != "" {
+		backupWindow = fmt.Sprintf("backup_window = %q", backupWindow)
+	}
+
 	return fmt.Sprintf(`
-resource "aws_db_instance" "source" {
-  allocated_storage       = 5
+resource "aws_db_instance" "test" {
+  allocated_storage       = 10
+  apply_immediately       = true
   backup_retention_period = 1
   engine                  = "mysql"
-  identifier              = "%s-source"
+  identifier              = %[1]q
   instance_class          = "db.t2.micro"
   password                = "avoid-plaintext-passwords"
-  username                = "tfacctest"
   skip_final_snapshot     = true
+  username                = "tfacctest"
+
+  %[2]s
+}
+`, rName, backupWindow)
 }
 
+func testAccAWSDBInstanceConfig_StorageType_Iops(rName, storageType string, iops int) string {
+	return fmt.Sprintf(`
 resource "aws_db_instance" "test" {
-  identifier          = %q
-  instance_class      = aws_db_instance.source.instance_class
-  port                = %d
-  replicate_source_db = aws_db_instance.source.id
+  allocated_storage   = 100
+  apply_immediately   = true
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t3.micro"
+  iops                = %[3]d
+  password            = "avoid-plaintext-passwords"
   skip_final_snapshot = true
+  storage_type        = %[2]q
+  username            = "tfacctest"
 }
-`, rName, rName, port)
+`, rName, storageType, iops)
 }
 
-func testAccAWSDBInstanceConfig_ReplicateSourceDb_VpcSecurityGroupIds(rName string) string {
+func testAccAWSDBInstanceConfig_StorageType(rName, storageType string) string {
+	if storageType != "" {
+		storageType = fmt.Sprintf("storage_type = %q", storageType)
+	}
+
 	return fmt.Sprintf(`
-data "aws_vpc" "default" {
-  default = true
+resource "aws_db_instance" "test" {
+  allocated_storage   = 10
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  username            = "tfacctest"
+
+  %[2]s
+}
+`, rName, storageType)
 }
 
-resource "aws_security_group" "test" {
-  name   = %q
-  vpc_id = data.aws_vpc.default.id
+func testAccAWSDBInstanceConfig_AllocatedStorage_Large(rName string, allocatedStorage int) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage   = %[2]d
+  engine              = "mysql"
+  identifier          = %[1]q
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  skip_final_snapshot = true
+  storage_type        = "gp2"
+  username            = "tfacctest"
+}
+`, rName, allocatedStorage)
 }
 
+func testAccAWSDBInstanceConfig_SnapshotIdentifier(rName string) string {
+	return fmt.Sprintf(`
 resource "aws_db_instance" "source" {
-  allocated_storage       = 5
-  backup_retention_period = 1
-  engine                  = "mysql"
-  identifier              = "%s-source"
-  instance_class          = "db.t2.micro"
-  password                = "avoid-plaintext-passwords"
-  username                = "tfacctest"
-  skip_final_snapshot     = true
+  allocated_storage   = 5
+  engine              = "mariadb"
+  identifier          = "%s-source"
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+
+resource "aws_db_snapshot" "test" {
+  db_instance_identifier = aws_db_instance.source.id
+  db_snapshot_identifier = %q
 }
 
 resource "aws_db_instance" "test" {
-  identifier             = %q
-  instance_class         = aws_db_instance.source.instance_class
-  replicate_source_db    = aws_db_instance.source.id
-  skip_final_snapshot    = true
-  vpc_security_group_ids = [aws_security_group.test.id]
+  identifier          = %q
+  instance_class      = aws_db_instance.source.instance_class
+  snapshot_identifier = aws_db_snapshot.test.id
+  skip_final_snapshot = true
 }
 `, rName, rName, rName)
 }
 
-func testAccAWSDBInstanceConfig_ReplicateSourceDb_CACertificateIdentifier(rName string, caName string) string {
+func testAccAWSDBInstanceConfig_SnapshotIdentifier_KmsKeyId(rName string, sourceEncrypted bool) string {
 	return fmt.Sprintf(`
+resource "aws_kms_key" "test" {
+  description             = %[1]q
+  deletion_window_in_days = 7
+}
+
 resource "aws_db_instance" "source" {
-  allocated_storage       = 5
-  backup_retention_period = 1
-  engine                  = "mysql"
-  identifier              = "%s-source"
-  instance_class          = "db.t2.micro"
-  password                = "avoid-plaintext-passwords"
-  username                = "tfacctest"
-  ca_cert_identifier      = %q
-  skip_final_snapshot     = true
+  allocated_storage   = 5
+  engine              = "mariadb"
+  identifier          = "%[1]s-source"
+  instance_class      = "db.t3.micro"
+  kms_key_id          = %[2]t ? aws_kms_key.test.arn : null
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+  storage_encrypted   = %[2]t
+}
+
+resource "aws_db_snapshot" "test" {
+  db_instance_identifier = aws_db_instance.source.id
+  db_snapshot_identifier = %[1]q
 }
 
 resource "aws_db_instance" "test" {
-  identifier          = %q
+  identifier          = %[1]q
   instance_class      = aws_db_instance.source.instance_class
-  replicate_source_db = aws_db_instance.source.id
-  ca_cert_identifier  = %q
+  kms_key_id          = aws_kms_key.test.arn
+  snapshot_identifier = aws_db_snapshot.test.id
   skip_final_snapshot = true
 }
-`, rName, caName, rName, caName)
+`, rName, sourceEncrypted)
 }
 
-func testAccAWSDBInstanceConfig_SnapshotIdentifier(rName string) string {
+func testAccAWSDBInstanceConfig_SnapshotIdentifier_StorageEncrypted(rName, storageEncrypted string) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "source" {
   allocated_storage   = 5
   engine              = "mariadb"
-  identifier          = "%s-source"
-  instance_class      = "db.t2.micro"
+  identifier          = "%[1]s-source"
+  instance_class      = "db.t3.micro"
   password            = "avoid-plaintext-passwords"
   username            = "tfacctest"
   skip_final_snapshot = true
+  storage_encrypted   = true
 }
 
 resource "aws_db_snapshot" "test" {
   db_instance_identifier = aws_db_instance.source.id
-  db_snapshot_identifier = %q
+  db_snapshot_identifier = %[1]q
 }
 
 resource "aws_db_instance" "test" {
-  identifier          = %q
+  identifier          = %[1]q
   instance_class      = aws_db_instance.source.instance_class
   snapshot_identifier = aws_db_snapshot.test.id
   skip_final_snapshot = true
+  storage_encrypted   = %[2]s
 }
-`, rName, rName, rName)
+`, rName, storageEncrypted)
+}
+
+func testAccAWSDBInstanceConfig_RestoreToPointInTime_DbSubnetGroupName(rName string) string {
+	return composeConfig(testAccAvailableAZsNoOptInConfig(), fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage       = 5
+  backup_retention_period = 1
+  engine                  = "mysql"
+  identifier              = "%[1]s-source"
+  instance_class          = "db.t2.micro"
+  password                = "avoid-plaintext-passwords"
+  username                = "tfacctest"
+  skip_final_snapshot     = true
+}
+
+resource "aws_vpc" "test" {
+  cidr_block = "10.1.0.0/16"
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_subnet" "test" {
+  count = 2
+
+  availability_zone = data.aws_availability_zones.available.names[count.index]
+  cidr_block        = "10.1.${count.index}.0/24"
+  vpc_id            = aws_vpc.test.id
+
+  tags = {
+    Name = %[1]q
+  }
+}
+
+resource "aws_db_subnet_group" "test" {
+  name       = %[1]q
+  subnet_ids = aws_subnet.test[*].id
+}
+
+resource "aws_security_group" "test" {
+  name   = %[1]q
+  vpc_id = aws_vpc.test.id
+}
+
+resource "aws_db_instance" "test" {
+  db_subnet_group_name   = aws_db_subnet_group.test.name
+  identifier             = %[1]q
+  instance_class         = aws_db_instance.source.instance_class
+  skip_final_snapshot    = true
+  vpc_security_group_ids = [aws_security_group.test.id]
+
+  restore_to_point_in_time {
+    source_db_instance_identifier = aws_db_instance.source.identifier
+    use_latest_restorable_time    = true
+  }
+}
+`, rName))
 }
 
 func testAccAWSDBInstanceConfig_SnapshotIdentifier_AllocatedStorage(rName string, allocatedStorage int) string {
@@ -5786,6 +10833,35 @@ resource "aws_db_instance" "test" {
 `, rName, allowMajorVersionUpgrade)
 }
 
+func testAccAWSDBInstanceConfig_SnapshotIdentifier_EngineVersion(rName, engineVersion string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "source" {
+  allocated_storage   = 5
+  engine              = "mysql"
+  engine_version      = %[2]q
+  identifier          = "%[1]s-source"
+  instance_class      = "db.t2.micro"
+  password            = "avoid-plaintext-passwords"
+  username            = "tfacctest"
+  skip_final_snapshot = true
+}
+
+resource "aws_db_snapshot" "test" {
+  db_instance_identifier = aws_db_instance.source.id
+  db_snapshot_identifier = %[1]q
+}
+
+resource "aws_db_instance" "test" {
+  engine              = "mysql"
+  engine_version      = %[2]q
+  identifier          = %[1]q
+  instance_class      = aws_db_instance.source.instance_class
+  snapshot_identifier = aws_db_snapshot.test.id
+  skip_final_snapshot = true
+}
+`, rName, engineVersion)
+}
+
 func testAccAWSDBInstanceConfig_SnapshotIdentifier_AutoMinorVersionUpgrade(rName string, autoMinorVersionUpgrade bool) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "source" {
@@ -6625,6 +11701,25 @@ resource "aws_db_instance" "test" {
 `, rName)
 }
 
+func testAccAWSDBInstancePerformanceInsightsDisabledRetentionPeriod(rName string, performanceInsightsRetentionPeriod int) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage                     = 5
+  backup_retention_period               = 0
+  engine                                = "mysql"
+  engine_version                        = "5.6.41"
+  identifier                            = %[1]q
+  instance_class                        = "db.m3.medium"
+  name                                  = "mydb"
+  password                              = "mustbeeightcharaters"
+  performance_insights_enabled          = false
+  performance_insights_retention_period = %[2]d
+  skip_final_snapshot                   = true
+  username                              = "foo"
+}
+`, rName, performanceInsightsRetentionPeriod)
+}
+
 func testAccAWSDBInstancePerformanceInsightsKmsKeyIdDisabled(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_kms_key" "test" {
@@ -6645,6 +11740,29 @@ resource "aws_db_instance" "test" {
 `, rName)
 }
 
+func testAccAWSDBInstancePerformanceInsightsKmsKeyIdNotEnabled(rName string) string {
+	return fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
+data "aws_partition" "current" {}
+
+data "aws_region" "current" {}
+
+resource "aws_db_instance" "test" {
+  allocated_storage               = 5
+  backup_retention_period         = 0
+  engine                          = "mysql"
+  identifier                      = %[1]q
+  instance_class                  = "db.m3.medium"
+  name                            = "mydb"
+  password                        = "mustbeeightcharaters"
+  performance_insights_kms_key_id = "arn:${data.aws_partition.current.partition}:kms:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+  skip_final_snapshot             = true
+  username                        = "foo"
+}
+`, rName)
+}
+
 func testAccAWSDBInstancePerformanceInsightsKmsKeyId(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_kms_key" "test" {
@@ -6788,6 +11906,28 @@ resource "aws_db_instance" "test" {
 `, rName, rName, rName)
 }
 
+func testAccAWSDBInstanceConfig_NoDeleteAutomatedBackups_Tags(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_instance" "test" {
+  allocated_storage     = 10
+  copy_tags_to_snapshot = true
+  engine                = "mariadb"
+  identifier            = %[1]q
+  instance_class        = "db.t2.micro"
+  password              = "avoid-plaintext-passwords"
+  username              = "tfacctest"
+  skip_final_snapshot   = true
+
+  backup_retention_period  = 1
+  delete_automated_backups = false
+
+  tags = {
+    Name = %[1]q
+  }
+}
+`, rName)
+}
+
 func testAccAWSDBInstanceConfig_NoDeleteAutomatedBackups(rName string) string {
 	return fmt.Sprintf(`
 resource "aws_db_instance" "test" {
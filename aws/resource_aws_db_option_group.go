@@ -250,6 +250,18 @@ func resourceAwsDbOptionGroupUpdate(d *schema.ResourceData, meta interface{}) er
 			optionsToRemove = append(optionsToRemove, optionToRemoveName)
 		}
 
+		// Options which depend on other options (e.g. APEX-DEV on APEX) must be
+		// added in the same request as, and after, the options they depend on.
+		if len(optionsToInclude) > 1 {
+			dependencies, err := resourceAwsDbOptionGroupOptionDependencies(rdsconn, d.Get("engine_name").(string), d.Get("major_engine_version").(string))
+
+			if err != nil {
+				return fmt.Errorf("error reading DB Option Group (%s) option dependencies: %s", d.Id(), err)
+			}
+
+			optionsToInclude = orderRdsOptionConfigurationsByDependency(optionsToInclude, dependencies)
+		}
+
 		// Ensure there is actually something to update
 		// InvalidParameterValue: At least one option must be added, modified, or removed.
 		if len(optionsToInclude) > 0 || len(optionsToRemove) > 0 {
@@ -329,6 +341,64 @@ func resourceAwsDbOptionGroupDelete(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
+// resourceAwsDbOptionGroupOptionDependencies returns the names of the options
+// each option depends on for the given engine and major engine version.
+func resourceAwsDbOptionGroupOptionDependencies(conn *rds.RDS, engineName, majorEngineVersion string) (map[string][]string, error) {
+	input := &rds.DescribeOptionGroupOptionsInput{
+		EngineName:         aws.String(engineName),
+		MajorEngineVersion: aws.String(majorEngineVersion),
+	}
+	dependencies := make(map[string][]string)
+
+	err := conn.DescribeOptionGroupOptionsPages(input, func(page *rds.DescribeOptionGroupOptionsOutput, lastPage bool) bool {
+		for _, option := range page.OptionGroupOptions {
+			if option == nil || len(option.OptionsDependedOn) == 0 {
+				continue
+			}
+
+			dependencies[aws.StringValue(option.Name)] = aws.StringValueSlice(option.OptionsDependedOn)
+		}
+
+		return !lastPage
+	})
+
+	return dependencies, err
+}
+
+// orderRdsOptionConfigurationsByDependency returns the option configurations
+// ordered so that any option comes after the options it depends on, otherwise
+// preserving the given order.
+func orderRdsOptionConfigurationsByDependency(options []*rds.OptionConfiguration, dependencies map[string][]string) []*rds.OptionConfiguration {
+	byName := make(map[string]*rds.OptionConfiguration, len(options))
+	for _, option := range options {
+		byName[aws.StringValue(option.OptionName)] = option
+	}
+
+	ordered := make([]*rds.OptionConfiguration, 0, len(options))
+	visited := make(map[string]bool, len(options))
+
+	var visit func(name string)
+	visit = func(name string) {
+		option, ok := byName[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true
+
+		for _, dependency := range dependencies[name] {
+			visit(dependency)
+		}
+
+		ordered = append(ordered, option)
+	}
+
+	for _, option := range options {
+		visit(aws.StringValue(option.OptionName))
+	}
+
+	return ordered
+}
+
 func flattenOptionNames(configured []interface{}) []*string {
 	var optionNames []*string
 	for _, pRaw := range configured {
@@ -72,6 +72,64 @@ func testSweepDbOptionGroups(region string) error {
 	return nil
 }
 
+func TestOrderRdsOptionConfigurationsByDependency(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Options      []string
+		Dependencies map[string][]string
+		Expected     []string
+	}{
+		{
+			Name:     "no dependencies",
+			Options:  []string{"Timezone", "SSL", "S3_INTEGRATION"},
+			Expected: []string{"Timezone", "SSL", "S3_INTEGRATION"},
+		},
+		{
+			Name:    "dependent before dependency",
+			Options: []string{"APEX-DEV", "Timezone", "APEX"},
+			Dependencies: map[string][]string{
+				"APEX-DEV": {"APEX"},
+			},
+			Expected: []string{"APEX", "APEX-DEV", "Timezone"},
+		},
+		{
+			Name:    "chained dependencies",
+			Options: []string{"C", "B", "A"},
+			Dependencies: map[string][]string{
+				"C": {"B"},
+				"B": {"A"},
+			},
+			Expected: []string{"A", "B", "C"},
+		},
+		{
+			Name:    "dependency already in option group",
+			Options: []string{"APEX-DEV", "Timezone"},
+			Dependencies: map[string][]string{
+				"APEX-DEV": {"APEX"},
+			},
+			Expected: []string{"APEX-DEV", "Timezone"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var options []*rds.OptionConfiguration
+			for _, name := range tc.Options {
+				options = append(options, &rds.OptionConfiguration{OptionName: aws.String(name)})
+			}
+
+			var got []string
+			for _, option := range orderRdsOptionConfigurationsByDependency(options, tc.Dependencies) {
+				got = append(got, aws.StringValue(option.OptionName))
+			}
+
+			if strings.Join(got, ",") != strings.Join(tc.Expected, ",") {
+				t.Errorf("got %v, expected %v", got, tc.Expected)
+			}
+		})
+	}
+}
+
 func TestAccAWSDBOptionGroup_basic(t *testing.T) {
 	var v rds.OptionGroup
 	rName := fmt.Sprintf("option-group-test-terraform-%s", acctest.RandString(5))
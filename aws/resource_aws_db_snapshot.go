@@ -23,7 +23,8 @@ func resourceAwsDbSnapshot() *schema.Resource {
 		},
 
 		Timeouts: &schema.ResourceTimeout{
-			Read: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -60,6 +61,7 @@ func resourceAwsDbSnapshot() *schema.Resource {
 			},
 			"engine_version": {
 				Type:     schema.TypeString,
+				Optional: true,
 				Computed: true,
 			},
 			"iops": {
@@ -143,6 +145,12 @@ func resourceAwsDbSnapshotCreate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if v, ok := d.GetOk("engine_version"); ok && v.(string) != aws.StringValue(resp.DBSnapshot.EngineVersion) {
+		if err := resourceAwsDbSnapshotModifyEngineVersion(d, meta, d.Timeout(schema.TimeoutRead)); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsDbSnapshotRead(d, meta)
 }
 
@@ -221,6 +229,12 @@ func resourceAwsDbSnapshotDelete(d *schema.ResourceData, meta interface{}) error
 func resourceAwsDbSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).rdsconn
 
+	if d.HasChange("engine_version") {
+		if err := resourceAwsDbSnapshotModifyEngineVersion(d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("tags") {
 		o, n := d.GetChange("tags")
 
@@ -229,6 +243,37 @@ func resourceAwsDbSnapshotUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	return resourceAwsDbSnapshotRead(d, meta)
+}
+
+// resourceAwsDbSnapshotModifyEngineVersion upgrades the DB snapshot to the
+// configured engine version and waits for the upgrade to complete.
+func resourceAwsDbSnapshotModifyEngineVersion(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	conn := meta.(*AWSClient).rdsconn
+
+	input := &rds.ModifyDBSnapshotInput{
+		DBSnapshotIdentifier: aws.String(d.Id()),
+		EngineVersion:        aws.String(d.Get("engine_version").(string)),
+	}
+
+	log.Printf("[DEBUG] Modifying DB Snapshot: %s", input)
+	if _, err := conn.ModifyDBSnapshot(input); err != nil {
+		return fmt.Errorf("error modifying DB Snapshot (%s) engine version: %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"modifying", "upgrading"},
+		Target:     []string{"available"},
+		Refresh:    resourceAwsDbSnapshotStateRefreshFunc(d, meta),
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+		Delay:      30 * time.Second, // Wait 30 secs before starting
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for DB Snapshot (%s) engine version upgrade: %s", d.Id(), err)
+	}
+
 	return nil
 }
 
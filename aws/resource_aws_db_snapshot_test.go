@@ -155,6 +155,34 @@ func TestAccAWSDBSnapshot_tags(t *testing.T) {
 	})
 }
 
+func TestAccAWSDBSnapshot_EngineVersion(t *testing.T) {
+	var v rds.DBSnapshot
+	resourceName := "aws_db_snapshot.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDbSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsDbSnapshotConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbSnapshotExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "engine_version", "5.6.35"),
+				),
+			},
+			{
+				Config: testAccAwsDbSnapshotConfigEngineVersion(rName, "5.7.19"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDbSnapshotExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "engine_version", "5.7.19"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAWSDBSnapshot_disappears(t *testing.T) {
 	var v rds.DBSnapshot
 	rName := acctest.RandomWithPrefix("tf-acc-test")
@@ -278,6 +306,16 @@ resource "aws_db_snapshot" "test" {
 `, rName)
 }
 
+func testAccAwsDbSnapshotConfigEngineVersion(rName, engineVersion string) string {
+	return testAccAwsDbSnapshotConfigBase(rName) + fmt.Sprintf(`
+resource "aws_db_snapshot" "test" {
+  db_instance_identifier = aws_db_instance.test.id
+  db_snapshot_identifier = %[1]q
+  engine_version         = %[2]q
+}
+`, rName, engineVersion)
+}
+
 func testAccAwsDbSnapshotConfigTags1(rName, tag1Key, tag1Value string) string {
 	return testAccAwsDbSnapshotConfigBase(rName) + fmt.Sprintf(`
 resource "aws_db_snapshot" "test" {
@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,6 +29,34 @@ func tagsSchemaComputed() *schema.Schema {
 	}
 }
 
+func tagsSchemaTrulyComputed() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// setTagsDiff sets tags_all to the resource tags merged with the provider
+// default tags, so that changes to either are shown in the plan.
+func setTagsDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+
+	resourceTags := keyvaluetags.New(diff.Get("tags").(map[string]interface{}))
+	allTags := defaultTagsConfig.MergeTags(resourceTags).IgnoreConfig(ignoreTagsConfig)
+
+	if err := diff.SetNew("tags_all", allTags.Map()); err != nil {
+		return fmt.Errorf("error setting new tags_all diff: %w", err)
+	}
+
+	return nil
+}
+
 func tagsSchemaForceNew() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeMap,
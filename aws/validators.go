@@ -1070,6 +1070,91 @@ func validateOnceADayWindowFormat(v interface{}, k string) (ws []string, errors
 	return
 }
 
+// dbInstanceWindowMinimumMinutes is the shortest backup or maintenance window
+// accepted by RDS for DB instances.
+const dbInstanceWindowMinimumMinutes = 30
+
+func validateDbInstanceBackupWindow(v interface{}, k string) (ws []string, errors []error) {
+	ws, errors = validateOnceADayWindowFormat(v, k)
+	if len(errors) > 0 {
+		return
+	}
+
+	if duration, ok := onceAWindowDurationMinutes(v.(string), 24*60); ok && duration < dbInstanceWindowMinimumMinutes {
+		errors = append(errors, fmt.Errorf(
+			"%q must be at least %d minutes long, got %q.", k, dbInstanceWindowMinimumMinutes, v.(string)))
+	}
+	return
+}
+
+func validateDbInstanceMaintenanceWindow(v interface{}, k string) (ws []string, errors []error) {
+	ws, errors = validateOnceAWeekWindowFormat(v, k)
+	if len(errors) > 0 {
+		return
+	}
+
+	if duration, ok := onceAWindowDurationMinutes(v.(string), 7*24*60); ok && duration < dbInstanceWindowMinimumMinutes {
+		errors = append(errors, fmt.Errorf(
+			"%q must be at least %d minutes long, got %q.", k, dbInstanceWindowMinimumMinutes, v.(string)))
+	}
+	return
+}
+
+// onceAWindowDurationMinutes returns the length of an already validated
+// "hh24:mi-hh24:mi" or "ddd:hh24:mi-ddd:hh24:mi" window. Windows wrap around
+// the end of the period, e.g. "23:45-00:15" is 30 minutes long, and a window
+// ending when it starts has no length.
+func onceAWindowDurationMinutes(window string, period int) (int, bool) {
+	parts := strings.Split(strings.ToLower(window), "-")
+	if len(parts) != 2 {
+		return 0, false
+	}
+
+	start, ok := onceAWindowMinutes(parts[0])
+	if !ok {
+		return 0, false
+	}
+
+	end, ok := onceAWindowMinutes(parts[1])
+	if !ok {
+		return 0, false
+	}
+
+	return ((end-start)%period + period) % period, true
+}
+
+// onceAWindowMinutes returns the minute offset of a "hh24:mi" time within the
+// day, or of a "ddd:hh24:mi" time within the week starting on Sunday.
+func onceAWindowMinutes(t string) (int, bool) {
+	fields := strings.Split(t, ":")
+
+	var minutes int
+	if len(fields) == 3 {
+		day := strings.Index("sunmontuewedthufrisat", fields[0])
+		if day < 0 || day%3 != 0 || len(fields[0]) != 3 {
+			return 0, false
+		}
+		minutes = day / 3 * 24 * 60
+		fields = fields[1:]
+	}
+
+	if len(fields) != 2 {
+		return 0, false
+	}
+
+	hours, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, false
+	}
+
+	mins, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return minutes + hours*60 + mins, true
+}
+
 // Validates that ECS Placement Constraints are set correctly
 // Takes type, and expression as strings
 func validateAwsEcsPlacementConstraint(constType, constExpr string) error {
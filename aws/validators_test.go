@@ -988,6 +988,16 @@ func TestValidateOnceAWeekWindowFormat(t *testing.T) {
 			Value:    "Sun:04:00-Sun:05:00",
 			ErrCount: 0,
 		},
+		{
+			// "SUN" can also be used
+			Value:    "SUN:04:00-MON:05:00",
+			ErrCount: 0,
+		},
+		{
+			// missing end of window
+			Value:    "sun:04:00",
+			ErrCount: 1,
+		},
 		{
 			// valid format
 			Value:    "",
@@ -1029,6 +1039,11 @@ func TestValidateOnceADayWindowFormat(t *testing.T) {
 			Value:    "04:00-05:00",
 			ErrCount: 0,
 		},
+		{
+			// missing leading zero
+			Value:    "4:00-05:00",
+			ErrCount: 1,
+		},
 		{
 			// valid format
 			Value:    "",
@@ -1045,6 +1060,133 @@ func TestValidateOnceADayWindowFormat(t *testing.T) {
 	}
 }
 
+func TestValidateDbInstanceBackupWindow(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			// once a week window format
+			Value:    "sun:04:00-sun:05:00",
+			ErrCount: 1,
+		},
+		{
+			// invalid hour
+			Value:    "24:00-25:00",
+			ErrCount: 1,
+		},
+		{
+			// too short
+			Value:    "04:00-04:29",
+			ErrCount: 1,
+		},
+		{
+			// no length
+			Value:    "04:00-04:00",
+			ErrCount: 1,
+		},
+		{
+			// too short, wrapping around midnight
+			Value:    "23:50-00:10",
+			ErrCount: 1,
+		},
+		{
+			// ends before it starts, so nearly a day long
+			Value:    "04:30-04:00",
+			ErrCount: 0,
+		},
+		{
+			// minimum length
+			Value:    "04:00-04:30",
+			ErrCount: 0,
+		},
+		{
+			// minimum length, wrapping around midnight
+			Value:    "23:45-00:15",
+			ErrCount: 0,
+		},
+		{
+			// valid format
+			Value:    "",
+			ErrCount: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateDbInstanceBackupWindow(tc.Value, "backup_window")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d validation errors, But got %d errors for \"%s\"", tc.ErrCount, len(errors), tc.Value)
+		}
+	}
+}
+
+func TestValidateDbInstanceMaintenanceWindow(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			// once a day window format
+			Value:    "04:00-05:00",
+			ErrCount: 1,
+		},
+		{
+			// invalid day of week
+			Value:    "san:04:00-san:05:00",
+			ErrCount: 1,
+		},
+		{
+			// too short
+			Value:    "mon:04:00-mon:04:15",
+			ErrCount: 1,
+		},
+		{
+			// no length
+			Value:    "Mon:04:00-mon:04:00",
+			ErrCount: 1,
+		},
+		{
+			// too short, wrapping around midnight
+			Value:    "mon:23:50-tue:00:10",
+			ErrCount: 1,
+		},
+		{
+			// too short, wrapping around the end of the week
+			Value:    "sat:23:50-sun:00:10",
+			ErrCount: 1,
+		},
+		{
+			// minimum length, wrapping around the end of the week
+			Value:    "sat:23:45-sun:00:15",
+			ErrCount: 0,
+		},
+		{
+			// ends before it starts, so nearly a week long
+			Value:    "tue:04:00-mon:04:00",
+			ErrCount: 0,
+		},
+		{
+			// valid format
+			Value:    "Mon:00:00-Mon:03:00",
+			ErrCount: 0,
+		},
+		{
+			// valid format
+			Value:    "",
+			ErrCount: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateDbInstanceMaintenanceWindow(tc.Value, "maintenance_window")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d validation errors, But got %d errors for \"%s\"", tc.ErrCount, len(errors), tc.Value)
+		}
+	}
+}
+
 func TestValidateEcsPlacementConstraint(t *testing.T) {
 	cases := []struct {
 		constType string